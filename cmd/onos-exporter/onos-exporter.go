@@ -7,29 +7,163 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
+	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 
+	"github.com/onosproject/onos-exporter/pkg/collect"
 	"github.com/onosproject/onos-exporter/pkg/config"
+	"github.com/onosproject/onos-exporter/pkg/discovery"
 	"github.com/onosproject/onos-exporter/pkg/export"
 )
 
 const (
 	endpoint_address          = ":9861"
 	endpoint_path             = "/metrics"
+	topologyPathDefault       = "/topology"
 	exporter_mode             = "prometheus"
 	e2tEndpointDefault        = "onos-e2t:5150"
 	xappPciEndpointDefault    = "onos-pci:5150"
 	xappKpimonEndpointDefault = "onos-kpimon:5150"
 	topoEndpointDefault       = "onos-topo:5150"
 	uenibEndpointDefault      = "onos-uenib:5150"
+	grpcPortDefault           = 5150
+	listCollectorsCommand     = "list-collectors"
+	discoveryIntervalDefault  = time.Minute
+	e2tSelectorDefault        = "name=onos-e2t"
+	xappPciSelectorDefault    = "name=onos-pci"
+	xappMhoSelectorDefault    = "name=onos-mho"
+	xappMlbSelectorDefault    = "name=onos-mlb"
+	xappKpimonSelectorDefault = "name=onos-kpimon"
+	topoSelectorDefault       = "name=onos-topo"
+	uenibSelectorDefault      = "name=onos-uenib"
+	configSelectorDefault     = "name=onos-config"
+	oidcClientSecretEnv       = "ONOS_EXPORTER_OIDC_CLIENT_SECRET"
 )
 
 var log = logging.GetLogger("main")
 
 var fatalErr error
 
+// printCollectors prints the registered collector types
+// and their specific configuration options.
+func printCollectors(w io.Writer) {
+	for _, colType := range collect.RegisteredTypes() {
+		fmt.Fprintf(w, "  %s\n    \t%s\n", colType.Name, colType.Description)
+		for _, option := range colType.Options {
+			fmt.Fprintf(w, "    %s\n      \t%s", option.Key, option.Description)
+			if option.Default != "" {
+				fmt.Fprintf(w, " (default %q)", option.Default)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage of %s:\n", os.Args[0])
+	fmt.Fprintf(out, "  %s [flags]\n", os.Args[0])
+	fmt.Fprintf(out, "  %s %s\n", os.Args[0], listCollectorsCommand)
+	fmt.Fprintln(out, "Flags:")
+	flag.PrintDefaults()
+	fmt.Fprintln(out, "Collectors:")
+	printCollectors(out)
+}
+
+// endpointPort returns the port of an endpoint in the form host:port,
+// or the default gRPC port if it does not define a valid one.
+func endpointPort(endpoint string) int {
+	_, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return grpcPortDefault
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return grpcPortDefault
+	}
+	return p
+}
+
+// instanceFlags defines a repeatable flag, used for the named collector
+// instances (name,type,address[,label=value...]) and their options
+// (name,key=value).
+type instanceFlags []string
+
+func (i *instanceFlags) String() string {
+	return strings.Join(*i, " ")
+}
+
+func (i *instanceFlags) Set(value string) error {
+	*i = append(*i, value)
+	return nil
+}
+
+// parseInstance parses a collector instance of instanceFlags,
+// returning its name and configuration. None of its fields can be empty.
+func parseInstance(instance string) (string, export.CollectorConfig, error) {
+	fields := strings.Split(instance, ",")
+	if len(fields) < 3 {
+		return "", export.CollectorConfig{}, fmt.Errorf("invalid collector instance %s, expected name,type,address[,label=value...]", instance)
+	}
+	for i, field := range fields {
+		fields[i] = strings.TrimSpace(field)
+		if fields[i] == "" {
+			return "", export.CollectorConfig{}, fmt.Errorf("invalid collector instance %s, empty field %d", instance, i+1)
+		}
+	}
+
+	labels := make(map[string]string)
+	for _, label := range fields[3:] {
+		kv := strings.SplitN(label, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return "", export.CollectorConfig{}, fmt.Errorf("invalid label %s of collector instance %s", label, fields[0])
+		}
+		labels[kv[0]] = kv[1]
+	}
+
+	colCfg := export.CollectorConfig{
+		Type:           fields[1],
+		ServiceAddress: fields[2],
+		Labels:         labels,
+	}
+	if err := colCfg.ValidateLabels(); err != nil {
+		return "", export.CollectorConfig{}, fmt.Errorf("invalid collector instance %s %s", fields[0], err)
+	}
+
+	return fields[0], colCfg, nil
+}
+
+// parseInstanceOption parses a collector instance option in the form
+// name,key=value, returning the instance name, option key and value.
+func parseInstanceOption(option string) (string, string, string, error) {
+	fields := strings.SplitN(option, ",", 2)
+	if len(fields) != 2 {
+		return "", "", "", fmt.Errorf("invalid collector instance option %s, expected name,key=value", option)
+	}
+	kv := strings.SplitN(fields[1], "=", 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return "", "", "", fmt.Errorf("invalid collector instance option %s, expected name,key=value", option)
+	}
+	return strings.TrimSpace(fields[0]), strings.TrimSpace(kv[0]), kv[1], nil
+}
+
+// splitCSV returns the non-empty values of a comma separated list.
+func splitCSV(values string) []string {
+	list := []string{}
+	for _, value := range strings.Split(values, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
 func fatal(e error) {
 	fmt.Println(e)
 	flag.PrintDefaults()
@@ -45,18 +179,58 @@ func main() {
 
 	address := flag.String("address", endpoint_address, "Exporter endpoint address:port or just :port")
 	path := flag.String("path", endpoint_path, "Exporter endpoint path be used to export kpis")
+	topologyPath := flag.String("topologyPath", topologyPathDefault, "Exporter endpoint path serving the topology as a graph (?format=dot|graphml|json), if empty it is not served")
 	mode := flag.String("mode", exporter_mode, "Exporter mode (e.g., prometheus, ...)")
 	caPath := flag.String("caPath", "", "path to CA certificate")
 	keyPath := flag.String("keyPath", "", "path to client private key")
 	certPath := flag.String("certPath", "", "path to client certificate")
 	e2tEndpoint := flag.String("e2tEndpoint", e2tEndpointDefault, "E2T service endpoint")
 	xappPciEndpoint := flag.String("xappPciEndpoint", xappPciEndpointDefault, "XApp PCI service endpoint")
+	xappMhoEndpoint := flag.String("xappMhoEndpoint", "", "XApp MHO service endpoint, if empty XApp MHO is not collected (e.g., onos-mho:5150)")
+	xappMlbEndpoint := flag.String("xappMlbEndpoint", "", "XApp MLB service endpoint, if empty XApp MLB is not collected (e.g., onos-mlb:5150)")
 	xappKpimonEndpoint := flag.String("xappKpimonEndpoint", xappKpimonEndpointDefault, "XApp Kpimon service endpoint")
 	topoEndpoint := flag.String("topoEndpoint", topoEndpointDefault, "Onos topo service endpoint")
 	uenibEndpoint := flag.String("uenibEndpoint", uenibEndpointDefault, "Onos uenib service endpoint")
+	configEndpoint := flag.String("configEndpoint", "", "Onos config service endpoint, if empty onos config is not collected (e.g., onos-config:5150)")
+	ransimEndpoint := flag.String("ransimEndpoint", "", "RAN simulator service endpoint, if empty the RAN simulator is not collected (e.g., ran-simulator:5150)")
+	authHeader := flag.String("authHeader", "", "Authorization header of gRPC calls in the form 'Bearer <token>'")
+	authTokenFile := flag.String("authTokenFile", "", "path to a bearer token file, reloaded on change")
+	oidcTokenURL := flag.String("oidcTokenURL", "", "OIDC token endpoint used by the client credentials flow")
+	oidcClientID := flag.String("oidcClientID", "", "OIDC client id")
+	oidcClientSecretFile := flag.String("oidcClientSecretFile", "", "path to a file containing the OIDC client secret, if empty the "+oidcClientSecretEnv+" environment variable is used")
+	oidcScopes := flag.String("oidcScopes", "", "comma separated list of OIDC scopes")
+	var instances instanceFlags
+	flag.Var(&instances, "instance", "additional collector instance name,type,address[,label=value...] (e.g., kpimon-site-a,onos-xappkpimon,kpimon-a:5150,site=a), can be repeated")
+	var instanceOptions instanceFlags
+	flag.Var(&instanceOptions, "instanceOption", "collector instance option name,key=value (e.g., myxapp,generic.config=/etc/onos/myxapp.yaml), can be repeated")
+	discoveryEnabled := flag.Bool("discovery", false, "discover the collector endpoints via the Kubernetes API")
+	kubeconfig := flag.String("kubeconfig", "", "path to a kubeconfig file, if empty the in cluster configuration is used")
+	discoveryNamespaces := flag.String("discoveryNamespaces", "", "comma separated list of namespaces to discover, if empty all namespaces")
+	discoveryInterval := flag.Duration("discoveryInterval", discoveryIntervalDefault, "interval between discovery refreshes")
+	discoveryRole := flag.String("discoveryRole", discovery.RolePod, "Kubernetes resources to discover (pod or service)")
+	e2tSelector := flag.String("e2tSelector", e2tSelectorDefault, "E2T discovery label selector, if empty E2T is not discovered")
+	xappPciSelector := flag.String("xappPciSelector", xappPciSelectorDefault, "XApp PCI discovery label selector, if empty XApp PCI is not discovered")
+	xappMhoSelector := flag.String("xappMhoSelector", xappMhoSelectorDefault, "XApp MHO discovery label selector, if empty XApp MHO is not discovered")
+	xappMlbSelector := flag.String("xappMlbSelector", xappMlbSelectorDefault, "XApp MLB discovery label selector, if empty XApp MLB is not discovered")
+	xappKpimonSelector := flag.String("xappKpimonSelector", xappKpimonSelectorDefault, "XApp Kpimon discovery label selector, if empty XApp Kpimon is not discovered")
+	topoSelector := flag.String("topoSelector", topoSelectorDefault, "Onos topo discovery label selector, if empty onos topo is not discovered")
+	uenibSelector := flag.String("uenibSelector", uenibSelectorDefault, "Onos uenib discovery label selector, if empty onos uenib is not discovered")
+	configSelector := flag.String("configSelector", configSelectorDefault, "Onos config discovery label selector, if empty onos config is not discovered")
+	ransimSelector := flag.String("ransimSelector", "", "RAN simulator discovery label selector, if empty the RAN simulator is not discovered (e.g., name=ran-simulator)")
 
+	flag.Usage = usage
 	flag.Parse()
 
+	if flag.Arg(0) == listCollectorsCommand {
+		printCollectors(os.Stdout)
+		return
+	}
+
+	if *topologyPath != "" && *topologyPath == *path {
+		fatal(fmt.Errorf("topology path %s must differ from the exporter path", *topologyPath))
+		return
+	}
+
 	log.Info("Starting onos-exporter")
 
 	cfgs := map[string]export.CollectorConfig{
@@ -76,15 +250,112 @@ func main() {
 			ServiceAddress: *uenibEndpoint,
 		},
 	}
+	// The optional collectors are added only if their endpoint is set.
+	for name, endpoint := range map[string]string{
+		config.ONOSXAPPMHO: *xappMhoEndpoint,
+		config.ONOSXAPPMLB: *xappMlbEndpoint,
+		config.ONOSCONFIG:  *configEndpoint,
+		config.ONOSRANSIM:  *ransimEndpoint,
+	} {
+		if endpoint != "" {
+			cfgs[name] = export.CollectorConfig{
+				ServiceAddress: endpoint,
+			}
+		}
+	}
+
+	typeSelectors := map[string]string{
+		config.ONOSE2T:        *e2tSelector,
+		config.ONOSXAPPPCI:    *xappPciSelector,
+		config.ONOSXAPPMHO:    *xappMhoSelector,
+		config.ONOSXAPPMLB:    *xappMlbSelector,
+		config.ONOSXAPPKPIMON: *xappKpimonSelector,
+		config.ONOSTOPO:       *topoSelector,
+		config.ONOSUENIB:      *uenibSelector,
+		config.ONOSCONFIG:     *configSelector,
+		config.ONOSRANSIM:     *ransimSelector,
+	}
+	// The discovered collectors of the optional types are configured
+	// as well, so they get the auth settings and their options.
+	if *discoveryEnabled {
+		for name, labelSelector := range typeSelectors {
+			if _, ok := cfgs[name]; !ok && labelSelector != "" {
+				cfgs[name] = export.CollectorConfig{}
+			}
+		}
+	}
+
+	for _, instance := range instances {
+		name, colCfg, err := parseInstance(instance)
+		if err != nil {
+			fatal(err)
+			return
+		}
+		if _, ok := cfgs[name]; ok {
+			fatal(fmt.Errorf("duplicated collector instance %s", name))
+			return
+		}
+		cfgs[name] = colCfg
+	}
+
+	for _, instanceOption := range instanceOptions {
+		name, key, value, err := parseInstanceOption(instanceOption)
+		if err != nil {
+			fatal(err)
+			return
+		}
+		colCfg, ok := cfgs[name]
+		if !ok {
+			fatal(fmt.Errorf("option %s of unknown collector instance %s", key, name))
+			return
+		}
+		if colCfg.Options == nil {
+			colCfg.Options = make(map[string]string)
+		}
+		colCfg.Options[key] = value
+		cfgs[name] = colCfg
+	}
+
+	for name, colCfg := range cfgs {
+		colCfg.AuthHeader = *authHeader
+		colCfg.AuthTokenFile = *authTokenFile
+		colCfg.OIDCTokenURL = *oidcTokenURL
+		colCfg.OIDCClientID = *oidcClientID
+		colCfg.OIDCClientSecret = os.Getenv(oidcClientSecretEnv)
+		colCfg.OIDCClientSecretFile = *oidcClientSecretFile
+		colCfg.OIDCScopes = *oidcScopes
+		cfgs[name] = colCfg
+	}
+
+	selectors := []discovery.Selector{}
+	for name, labelSelector := range typeSelectors {
+		if labelSelector == "" {
+			continue
+		}
+		selectors = append(selectors, discovery.Selector{
+			Type:          name,
+			Role:          *discoveryRole,
+			LabelSelector: labelSelector,
+			Port:          endpointPort(cfgs[name].ServiceAddress),
+		})
+	}
 
 	cfg := export.Config{
 		Address:           *address,
 		Path:              *path,
+		TopologyPath:      *topologyPath,
 		Mode:              *mode,
 		CAPath:            *caPath,
 		KeyPath:           *keyPath,
 		CertPath:          *certPath,
 		CollectorsConfigs: cfgs,
+		Discovery: export.DiscoveryConfig{
+			Enabled:    *discoveryEnabled,
+			Kubeconfig: *kubeconfig,
+			Namespaces: splitCSV(*discoveryNamespaces),
+			Interval:   *discoveryInterval,
+			Selectors:  selectors,
+		},
 	}
 
 	exporter := export.NewExporter(cfg)
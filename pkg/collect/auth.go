@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "Bearer "
+
+	// oidcExpiryDelta defines how long before its expiration
+	// an OIDC access token is renewed.
+	oidcExpiryDelta = 30 * time.Second
+	oidcTimeout     = 10 * time.Second
+)
+
+// TokenSource defines the behavior of a source of authorization
+// headers, used to authenticate the gRPC calls of a collector.
+// The ctx is the one of the gRPC call being authenticated.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// staticTokenSource always returns the same authorization header.
+type staticTokenSource struct {
+	header string
+}
+
+func (s *staticTokenSource) Token(ctx context.Context) (string, error) {
+	return s.header, nil
+}
+
+// fileTokenSource reads a bearer token from a file, reloading it
+// whenever the file modification time changes (e.g., Kubernetes
+// projected service account tokens).
+type fileTokenSource struct {
+	path    string
+	mu      sync.Mutex
+	modTime time.Time
+	token   string
+}
+
+func (f *fileTokenSource) Token(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	if f.token == "" || !info.ModTime().Equal(f.modTime) {
+		data, err := ioutil.ReadFile(f.path)
+		if err != nil {
+			return "", err
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("empty token file %s", f.path)
+		}
+		f.token = token
+		f.modTime = info.ModTime()
+	}
+
+	return bearerPrefix + f.token, nil
+}
+
+// oidcTokenSource retrieves access tokens from an OIDC provider using
+// the client credentials flow, caching each token until it is about
+// to expire. If clientSecretFile is defined, the client secret is
+// read from it on each token request (e.g., a mounted Kubernetes secret).
+// The token requests are bounded by the ctx of the gRPC calls too.
+type oidcTokenSource struct {
+	tokenURL         string
+	clientID         string
+	clientSecret     string
+	clientSecretFile string
+	scopes           []string
+	client           *http.Client
+	mu               sync.Mutex
+	token            string
+	expiry           time.Time
+}
+
+type oidcTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (o *oidcTokenSource) Token(ctx context.Context) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.token != "" && (o.expiry.IsZero() || time.Now().Add(oidcExpiryDelta).Before(o.expiry)) {
+		return bearerPrefix + o.token, nil
+	}
+
+	clientSecret := o.clientSecret
+	if o.clientSecretFile != "" {
+		data, err := ioutil.ReadFile(o.clientSecretFile)
+		if err != nil {
+			return "", err
+		}
+		clientSecret = strings.TrimSpace(string(data))
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(o.scopes) > 0 {
+		form.Set("scope", strings.Join(o.scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(o.clientID), url.QueryEscape(clientSecret))
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("oidc token request to %s failed with status %s", o.tokenURL, resp.Status)
+	}
+
+	tokenResp := oidcTokenResponse{}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", err
+	}
+	if tokenResp.AccessToken == "" {
+		return "", fmt.Errorf("oidc token response from %s has no access token", o.tokenURL)
+	}
+
+	o.token = tokenResp.AccessToken
+	o.expiry = time.Time{}
+	if tokenResp.ExpiresIn > 0 {
+		o.expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+
+	return bearerPrefix + o.token, nil
+}
+
+// newTokenSource creates the TokenSource defined by a Configuration.
+// A static auth header takes precedence over a token file, which takes
+// precedence over the OIDC client credentials flow. If none of them is
+// configured, it returns nil.
+func newTokenSource(c Configuration) (TokenSource, error) {
+	switch {
+	case c.getAuthHeader() != "":
+		return &staticTokenSource{header: c.getAuthHeader()}, nil
+	case c.getAuthTokenFile() != "":
+		return &fileTokenSource{path: c.getAuthTokenFile()}, nil
+	case c.getOIDCTokenURL() != "":
+		if c.getOIDCClientID() == "" {
+			return nil, fmt.Errorf("oidc token url %s configured without client id", c.getOIDCTokenURL())
+		}
+		return &oidcTokenSource{
+			tokenURL:         c.getOIDCTokenURL(),
+			clientID:         c.getOIDCClientID(),
+			clientSecret:     c.getOIDCClientSecret(),
+			clientSecretFile: c.getOIDCSecretFile(),
+			scopes:           c.getOIDCScopes(),
+			client:           &http.Client{Timeout: oidcTimeout},
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// tokenCredentials implements the credentials.PerRPCCredentials
+// interface, attaching the authorization header of a TokenSource
+// to every gRPC call.
+type tokenCredentials struct {
+	source TokenSource
+	secure bool
+}
+
+func (t *tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	header, err := t.source.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{authorizationHeader: header}, nil
+}
+
+func (t *tokenCredentials) RequireTransportSecurity() bool {
+	return t.secure
+}
+
+// newCredentials returns the per RPC credentials of a Configuration,
+// or nil if it does not define any authorization.
+func newCredentials(c Configuration) (credentials.PerRPCCredentials, error) {
+	source, err := newTokenSource(c)
+	if err != nil || source == nil {
+		return nil, err
+	}
+
+	return &tokenCredentials{
+		source: source,
+		secure: !c.noTLS(),
+	}, nil
+}
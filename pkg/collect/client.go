@@ -12,8 +12,10 @@ import (
 	"google.golang.org/grpc/credentials"
 )
 
-// GetConnection returns a gRPC client connection to the onos service
-func GetConnection(address, certPath, keyPath string, noTls bool) (*grpc.ClientConn, error) {
+// GetConnection returns a gRPC client connection to the onos service.
+// Additional dial options (e.g., per RPC credentials) are appended to
+// the ones defining the transport security.
+func GetConnection(address, certPath, keyPath string, noTls bool, dialOpts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	var opts []grpc.DialOption
 
 	if noTls {
@@ -47,6 +49,8 @@ func GetConnection(address, certPath, keyPath string, noTls bool) (*grpc.ClientC
 		}
 	}
 
+	opts = append(opts, dialOpts...)
+
 	conn, err := grpc.Dial(address, opts...)
 	if err != nil {
 		return nil, err
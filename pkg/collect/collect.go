@@ -8,13 +8,30 @@ import (
 	"fmt"
 	"sync"
 
-	exporterConfig "github.com/onosproject/onos-exporter/pkg/config"
+	"github.com/onosproject/onos-exporter/pkg/discovery"
 	"github.com/onosproject/onos-exporter/pkg/kpis"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var log = logging.GetLogger("collect")
 
+// InstanceLabel is the label added to the metrics of a named collector
+// instance, i.e., an instance whose name differs from its collector type.
+// It is not named instance, which prometheus assigns to the scrape target.
+const InstanceLabel = "onos_instance"
+
+// ExporterLabels are the labels added by the exporter to the metrics
+// of the collector instances (see WithLabels), along with the labels
+// defined for each instance.
+var ExporterLabels = []string{
+	InstanceLabel,
+	discovery.NamespaceLabel,
+	discovery.PodLabel,
+	discovery.ServiceLabel,
+}
+
 // Collector defines an interface for Collectors to retrieve
 // a list of kpis.KPI via the Collect method.
 type Collector interface {
@@ -22,66 +39,78 @@ type Collector interface {
 }
 
 type collector struct {
-	name   string
-	config Configuration
+	name        string
+	config      Configuration
+	credentials credentials.PerRPCCredentials
 }
 
 func (col *collector) Collect() ([]kpis.KPI, error) {
 	return []kpis.KPI{}, nil
 }
 
-// CreateCollector instantiates a new collector based on the const
-// name of the collector specified. Available collectors must be defined
-// in the cost set of strings.
-func CreateCollector(name, serviceAddress string) (Collector, error) {
-	colConfig := InitConfig(name)
-	err := colConfig.set(map[string]string{addressKey: serviceAddress})
+// Name returns the name of the collector type.
+func (col *collector) Name() string {
+	return col.name
+}
 
-	if err != nil {
-		return &collector{}, fmt.Errorf("could not configure collector %s error %s", name, err)
+// Option returns the value of the configuration option key.
+func (col *collector) Option(key string) string {
+	return col.config.getOption(key)
+}
 
+// Connect returns a gRPC client connection to the service address
+// of the collector, attaching its credentials to every call.
+func (col *collector) Connect() (*grpc.ClientConn, error) {
+	dialOpts := []grpc.DialOption{}
+	if col.credentials != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(col.credentials))
 	}
 
-	switch name {
-	case exporterConfig.ONOSE2T:
-		return &onose2tCollector{
-			collector: collector{
-				name:   name,
-				config: colConfig,
-			},
-		}, nil
-	case exporterConfig.ONOSXAPPKPIMON:
-		return &xappKpimonCollector{
-			collector: collector{
-				name:   name,
-				config: colConfig,
-			},
-		}, nil
-	case exporterConfig.ONOSXAPPPCI:
-		return &xappPciCollector{
-			collector: collector{
-				name:   name,
-				config: colConfig,
-			},
-		}, nil
-	case exporterConfig.ONOSTOPO:
-		return &onosTopoCollector{
-			collector: collector{
-				name:   name,
-				config: colConfig,
-			},
-		}, nil
-	case exporterConfig.ONOSUENIB:
-		return &onosUenibCollector{
-			collector: collector{
-				name:   name,
-				config: colConfig,
-			},
-		}, nil
-	default:
+	return GetConnection(
+		col.config.getAddress(),
+		col.config.getCertPath(),
+		col.config.getKeyPath(),
+		col.config.noTLS(),
+		dialOpts...,
+	)
+}
+
+// baseCollector returns the collector implementing a Base
+// created by CreateCollector, so it can be embedded by the
+// collectors of this package.
+func baseCollector(base Base) collector {
+	return *base.(*collector)
+}
+
+// CreateCollector instantiates a new collector based on the name
+// of a registered collector type (see Register). The options, keyed
+// by the option names defined in config.go (e.g., AddressKey) or in
+// the collector type schema, configure the collector.
+func CreateCollector(name string, options map[string]string) (Collector, error) {
+	colType, ok := LookupType(name)
+	if !ok {
 		return &collector{}, fmt.Errorf("no collector found with name %s", name)
+	}
+
+	colConfig := InitConfig(name, colType.optionKeys()...)
+	colConfig.setDefaults(colType.optionDefaults())
+	err := colConfig.set(options)
+
+	if err != nil {
+		return &collector{}, fmt.Errorf("could not configure collector %s error %s", name, err)
 
 	}
+
+	creds, err := newCredentials(colConfig)
+	if err != nil {
+		return &collector{}, fmt.Errorf("could not configure collector %s credentials error %s", name, err)
+	}
+
+	return colType.Factory(&collector{
+		name:        name,
+		config:      colConfig,
+		credentials: creds,
+	})
 }
 
 // KPIs retrieves the list of kpis.KPI from each Collector.
@@ -113,3 +142,35 @@ func KPIs(collectors []Collector) []kpis.KPI {
 
 	return kpis
 }
+
+// labeledCollector wraps a Collector, adding a set of labels
+// to each one of the kpis.KPI it collects.
+type labeledCollector struct {
+	Collector
+	labels map[string]string
+}
+
+// WithLabels returns a Collector that outputs the kpis.KPI of col
+// with the labels added to each one of their metrics.
+func WithLabels(col Collector, labels map[string]string) Collector {
+	if len(labels) == 0 {
+		return col
+	}
+	return &labeledCollector{
+		Collector: col,
+		labels:    labels,
+	}
+}
+
+// Collect implements the Collector interface behavior for
+// labeledCollector, returning a list of labeled kpis.KPI.
+func (col *labeledCollector) Collect() ([]kpis.KPI, error) {
+	colKPIs, err := col.Collector.Collect()
+
+	labeledKPIs := make([]kpis.KPI, 0, len(colKPIs))
+	for _, kpi := range colKPIs {
+		labeledKPIs = append(labeledKPIs, kpis.WithLabels(kpi, col.labels))
+	}
+
+	return labeledKPIs, err
+}
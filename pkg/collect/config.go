@@ -6,27 +6,58 @@ package collect
 
 import (
 	"os"
+	"strings"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/viper"
 )
 
 const (
-	configDir  = ".onos"
-	addressKey = "service-address"
+	configDir = ".onos"
+)
+
+// Consts define the names of the options accepted by the
+// configuration of a collector.
+const (
+	AddressKey = "service-address"
 
-	tlsCertPathKey = "tls.certPath"
-	tlsKeyPathKey  = "tls.keyPath"
-	noTLSKey       = "no-tls"
-	authHeaderKey  = "auth-header"
+	TLSCertPathKey      = "tls.certPath"
+	TLSKeyPathKey       = "tls.keyPath"
+	NoTLSKey            = "no-tls"
+	AuthHeaderKey       = "auth-header"
+	AuthTokenFileKey    = "auth-token-file"
+	OIDCTokenURLKey     = "oidc.tokenURL"
+	OIDCClientIDKey     = "oidc.clientID"
+	OIDCClientSecretKey = "oidc.clientSecret"
+	OIDCSecretFileKey   = "oidc.clientSecretFile"
+	OIDCScopesKey       = "oidc.scopes"
 )
 
 var configOptions = []string{
-	addressKey,     // The gRPC endpoint
-	tlsCertPathKey, // The path to the TLS certificate
-	tlsKeyPathKey,  // The path to the TLS key
-	noTLSKey,       // If present, do not use TLS
-	authHeaderKey,  // Auth header in the form 'Bearer <base64>'
+	AddressKey,          // The gRPC endpoint
+	TLSCertPathKey,      // The path to the TLS certificate
+	TLSKeyPathKey,       // The path to the TLS key
+	NoTLSKey,            // If present, do not use TLS
+	AuthHeaderKey,       // Auth header in the form 'Bearer <base64>'
+	AuthTokenFileKey,    // The path to a file containing a bearer token
+	OIDCTokenURLKey,     // The OIDC token endpoint for the client credentials flow
+	OIDCClientIDKey,     // The OIDC client id
+	OIDCClientSecretKey, // The OIDC client secret
+	OIDCSecretFileKey,   // The path to a file containing the OIDC client secret
+	OIDCScopesKey,       // The comma separated OIDC scopes
+}
+
+// authOptions are not read from the configuration file, they are only
+// defined by the options of a collector instance, so credentials are
+// never persisted in plaintext.
+var authOptions = map[string]bool{
+	AuthHeaderKey:       true,
+	AuthTokenFileKey:    true,
+	OIDCTokenURLKey:     true,
+	OIDCClientIDKey:     true,
+	OIDCClientSecretKey: true,
+	OIDCSecretFileKey:   true,
+	OIDCScopesKey:       true,
 }
 
 // Configuration defines the methods expected to fulfill
@@ -38,30 +69,52 @@ type Configuration interface {
 	getCertPath() string
 	getKeyPath() string
 	noTLS() bool
+	getAuthHeader() string
+	getAuthTokenFile() string
+	getOIDCTokenURL() string
+	getOIDCClientID() string
+	getOIDCClientSecret() string
+	getOIDCSecretFile() string
+	getOIDCScopes() []string
+	getOption(key string) string
+	setDefaults(map[string]string)
 }
 
-func NewConfig(subsystem string) Configuration {
+// NewConfig creates the Configuration of a subsystem, accepting the
+// common configOptions and the subsystem specific extraOptions.
+func NewConfig(subsystem string, extraOptions ...string) Configuration {
 	opts := make(map[string]string)
 	for _, optName := range configOptions {
 		opts[optName] = ""
 	}
+	for _, optName := range extraOptions {
+		opts[optName] = ""
+	}
 
 	return config{
 		subsystem: subsystem,
 		options:   opts,
+		viper:     viper.New(),
 	}
 }
 
-// config implements the Configuration interface, using the
-// viper package to maintain the state of its data.
+// config implements the Configuration interface, using its own
+// viper instance to read the defaults of its options from the
+// configuration file of its subsystem. The options set for a
+// collector instance are kept only in its options, so they are
+// not inherited by other instances of the same subsystem.
 type config struct {
 	subsystem string
 	options   map[string]string
+	viper     *viper.Viper
 }
 
 func (c config) init() {
 	for opt := range c.options {
-		c.options[opt] = viper.GetString(opt)
+		if authOptions[opt] {
+			continue
+		}
+		c.options[opt] = c.viper.GetString(opt)
 	}
 }
 
@@ -69,36 +122,45 @@ func (c config) set(options map[string]string) error {
 	for opt, value := range options {
 		if _, ok := c.options[opt]; ok {
 			c.options[opt] = value
-			viper.Set(opt, value)
 		}
 	}
-	if err := viper.WriteConfig(); err != nil {
-		return err
-	}
 
 	return nil
 }
 
+// setDefaults sets the value of the options not defined yet.
+func (c config) setDefaults(defaults map[string]string) {
+	for opt, value := range defaults {
+		if current, ok := c.options[opt]; ok && current == "" {
+			c.options[opt] = value
+		}
+	}
+}
+
+func (c config) getOption(key string) string {
+	return c.options[key]
+}
+
 func (c config) getAddress() string {
-	address := c.options[addressKey]
+	address := c.options[AddressKey]
 	if address == "" {
-		return viper.GetString(addressKey)
+		return c.viper.GetString(AddressKey)
 	}
 	return address
 }
 
 func (c config) getCertPath() string {
-	certPath := c.options[tlsCertPathKey]
+	certPath := c.options[TLSCertPathKey]
 	return certPath
 }
 
 func (c config) getKeyPath() string {
-	keyPath := c.options[tlsKeyPathKey]
+	keyPath := c.options[TLSKeyPathKey]
 	return keyPath
 }
 
 func (c config) noTLS() bool {
-	tls := c.options[noTLSKey]
+	tls := c.options[NoTLSKey]
 
 	if tls == "" {
 		return false
@@ -107,8 +169,42 @@ func (c config) noTLS() bool {
 	}
 }
 
-func runConfigInitCommand(configName string) error {
-	if err := viper.ReadInConfig(); err == nil {
+func (c config) getAuthHeader() string {
+	return c.options[AuthHeaderKey]
+}
+
+func (c config) getAuthTokenFile() string {
+	return c.options[AuthTokenFileKey]
+}
+
+func (c config) getOIDCTokenURL() string {
+	return c.options[OIDCTokenURLKey]
+}
+
+func (c config) getOIDCClientID() string {
+	return c.options[OIDCClientIDKey]
+}
+
+func (c config) getOIDCClientSecret() string {
+	return c.options[OIDCClientSecretKey]
+}
+
+func (c config) getOIDCSecretFile() string {
+	return c.options[OIDCSecretFileKey]
+}
+
+func (c config) getOIDCScopes() []string {
+	scopes := []string{}
+	for _, scope := range strings.Split(c.options[OIDCScopesKey], ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+func runConfigInitCommand(v *viper.Viper, configName string) error {
+	if err := v.ReadInConfig(); err == nil {
 		return nil
 	}
 
@@ -128,7 +224,7 @@ func runConfigInitCommand(configName string) error {
 	}
 	_ = f.Close()
 
-	if err := viper.WriteConfig(); err != nil {
+	if err := v.WriteConfig(); err != nil {
 		return err
 	}
 
@@ -136,25 +232,26 @@ func runConfigInitCommand(configName string) error {
 }
 
 // InitConfig defines the Configuration to be used for the
-// creation of a connection to a onos service.
-func InitConfig(configNameInit string) Configuration {
+// creation of a connection to a onos service, accepting the
+// extraOptions in addition to the common ones.
+func InitConfig(configNameInit string, extraOptions ...string) Configuration {
 	home, err := homedir.Dir()
 	if err != nil {
 		panic(err)
 	}
-	viper.SetConfigName(configNameInit)
-	viper.AddConfigPath(home + "/" + configDir)
-	viper.AddConfigPath("/etc/onos")
-	viper.AddConfigPath(".")
+	config := NewConfig(configNameInit, extraOptions...).(config)
+	config.viper.SetConfigName(configNameInit)
+	config.viper.AddConfigPath(home + "/" + configDir)
+	config.viper.AddConfigPath("/etc/onos")
+	config.viper.AddConfigPath(".")
 
-	err = runConfigInitCommand(configNameInit)
+	err = runConfigInitCommand(config.viper, configNameInit)
 	if err != nil {
 		panic(err)
 	}
 
-	_ = viper.ReadInConfig()
+	_ = config.viper.ReadInConfig()
 
-	config := NewConfig(configNameInit)
 	config.init()
 	return config
 }
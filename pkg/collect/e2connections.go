@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-exporter/pkg/kpis"
+)
+
+// e2ConnectionTracker tracks the identities of the e2 connections
+// listed by e2t across collections. Connections appearing or vanishing
+// between two collections count as connects and disconnects of their
+// nodes, which are flagged as flapping if their number within window
+// reaches threshold. A connection whose reported age decreases, or whose
+// node changes, between two collections was reestablished with the same
+// identity, counting as a disconnect and a connect. The first collection
+// only defines the baseline of the connections, so it does not count any
+// connect. Nodes without connections nor events within window are pruned.
+type e2ConnectionTracker struct {
+	window    time.Duration
+	threshold int
+
+	mu          sync.Mutex
+	initialized bool
+	connections map[string]trackedE2Connection
+	nodes       map[string]*trackedE2Node
+}
+
+type trackedE2Connection struct {
+	nodeID    string
+	firstSeen time.Time
+	age       float64
+}
+
+type trackedE2Node struct {
+	connects    float64
+	disconnects float64
+	events      []time.Time
+}
+
+func newE2ConnectionTracker(window time.Duration, threshold int) *e2ConnectionTracker {
+	return &e2ConnectionTracker{
+		window:      window,
+		threshold:   threshold,
+		connections: make(map[string]trackedE2Connection),
+		nodes:       make(map[string]*trackedE2Node),
+	}
+}
+
+// update records the connections of a collection made at now, setting the
+// age of the connections not reporting it to the time since the tracker
+// first saw them.
+func (t *e2ConnectionTracker) update(connections map[string]kpis.E2tConnection, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for id, connection := range connections {
+		tracked, ok := t.connections[id]
+		reconnected := ok && (tracked.nodeID != connection.NodeId || (connection.Age > 0 && connection.Age < tracked.age))
+		if reconnected {
+			t.disconnect(tracked.nodeID, now)
+		}
+		if !ok || reconnected {
+			tracked = trackedE2Connection{
+				nodeID:    connection.NodeId,
+				firstSeen: now,
+			}
+			node := t.node(connection.NodeId)
+			if t.initialized {
+				node.connects++
+				node.events = append(node.events, now)
+			}
+		}
+		tracked.age = connection.Age
+		t.connections[id] = tracked
+
+		if connection.Age == 0 {
+			connection.Age = now.Sub(tracked.firstSeen).Seconds()
+			connections[id] = connection
+		}
+	}
+
+	for id, tracked := range t.connections {
+		if _, ok := connections[id]; ok {
+			continue
+		}
+		delete(t.connections, id)
+		t.disconnect(tracked.nodeID, now)
+	}
+
+	connected := make(map[string]bool)
+	for _, tracked := range t.connections {
+		connected[tracked.nodeID] = true
+	}
+
+	for nodeID, node := range t.nodes {
+		events := node.events[:0]
+		for _, event := range node.events {
+			if now.Sub(event) < t.window {
+				events = append(events, event)
+			}
+		}
+		node.events = events
+
+		if len(events) == 0 && !connected[nodeID] {
+			delete(t.nodes, nodeID)
+		}
+	}
+
+	t.initialized = true
+}
+
+// disconnect records a disconnect of the node nodeID made at now.
+func (t *e2ConnectionTracker) disconnect(nodeID string, now time.Time) {
+	node := t.node(nodeID)
+	node.disconnects++
+	node.events = append(node.events, now)
+}
+
+func (t *e2ConnectionTracker) node(nodeID string) *trackedE2Node {
+	node, ok := t.nodes[nodeID]
+	if !ok {
+		node = &trackedE2Node{}
+		t.nodes[nodeID] = node
+	}
+	return node
+}
+
+// KPI returns the kpi OnosE2tNodeLifecycle of the tracked nodes.
+func (t *e2ConnectionTracker) KPI() kpis.KPI {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	OnosE2tNodeLifecycleKPI := kpis.OnosE2tNodeLifecycle()
+	OnosE2tNodeLifecycleKPI.Nodes = make(map[string]kpis.E2tNodeLifecycle)
+
+	for nodeID, node := range t.nodes {
+		OnosE2tNodeLifecycleKPI.Nodes[nodeID] = kpis.E2tNodeLifecycle{
+			NodeId:      nodeID,
+			Connects:    node.connects,
+			Disconnects: node.disconnects,
+			Flapping:    len(node.events) >= t.threshold,
+		}
+	}
+
+	return OnosE2tNodeLifecycleKPI
+}
@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-exporter/pkg/kpis"
+	"github.com/stretchr/testify/assert"
+)
+
+// e2Collection is a collection of e2 connections made at a time
+// since the start of a test.
+type e2Collection struct {
+	at          time.Duration
+	connections map[string]kpis.E2tConnection
+}
+
+func e2Connection(nodeID string, age float64) kpis.E2tConnection {
+	return kpis.E2tConnection{NodeId: nodeID, Age: age}
+}
+
+func TestE2ConnectionTracker(t *testing.T) {
+	tests := []struct {
+		name        string
+		collections []e2Collection
+		expected    map[string]kpis.E2tNodeLifecycle
+	}{
+		{
+			name: "baseline",
+			collections: []e2Collection{
+				{at: 0, connections: map[string]kpis.E2tConnection{
+					"c1": e2Connection("node1", 100),
+					"c2": e2Connection("node2", 200),
+				}},
+			},
+			expected: map[string]kpis.E2tNodeLifecycle{
+				"node1": {NodeId: "node1"},
+				"node2": {NodeId: "node2"},
+			},
+		},
+		{
+			name: "connect",
+			collections: []e2Collection{
+				{at: 0, connections: map[string]kpis.E2tConnection{
+					"c1": e2Connection("node1", 100),
+				}},
+				{at: time.Minute, connections: map[string]kpis.E2tConnection{
+					"c1": e2Connection("node1", 160),
+					"c2": e2Connection("node2", 10),
+				}},
+			},
+			expected: map[string]kpis.E2tNodeLifecycle{
+				"node1": {NodeId: "node1"},
+				"node2": {NodeId: "node2", Connects: 1},
+			},
+		},
+		{
+			name: "disconnect",
+			collections: []e2Collection{
+				{at: 0, connections: map[string]kpis.E2tConnection{
+					"c1": e2Connection("node1", 100),
+				}},
+				{at: time.Minute, connections: map[string]kpis.E2tConnection{}},
+			},
+			expected: map[string]kpis.E2tNodeLifecycle{
+				"node1": {NodeId: "node1", Disconnects: 1},
+			},
+		},
+		{
+			name: "reconnect with decreasing age",
+			collections: []e2Collection{
+				{at: 0, connections: map[string]kpis.E2tConnection{
+					"c1": e2Connection("node1", 100),
+				}},
+				{at: time.Minute, connections: map[string]kpis.E2tConnection{
+					"c1": e2Connection("node1", 5),
+				}},
+			},
+			expected: map[string]kpis.E2tNodeLifecycle{
+				"node1": {NodeId: "node1", Connects: 1, Disconnects: 1},
+			},
+		},
+		{
+			name: "reconnect with another node",
+			collections: []e2Collection{
+				{at: 0, connections: map[string]kpis.E2tConnection{
+					"c1": e2Connection("node1", 100),
+				}},
+				{at: time.Minute, connections: map[string]kpis.E2tConnection{
+					"c1": e2Connection("node2", 160),
+				}},
+			},
+			expected: map[string]kpis.E2tNodeLifecycle{
+				"node1": {NodeId: "node1", Disconnects: 1},
+				"node2": {NodeId: "node2", Connects: 1},
+			},
+		},
+		{
+			name: "increasing age without reconnect",
+			collections: []e2Collection{
+				{at: 0, connections: map[string]kpis.E2tConnection{
+					"c1": e2Connection("node1", 0),
+				}},
+				{at: time.Minute, connections: map[string]kpis.E2tConnection{
+					"c1": e2Connection("node1", 0),
+				}},
+				{at: 2 * time.Minute, connections: map[string]kpis.E2tConnection{
+					"c1": e2Connection("node1", 130),
+				}},
+			},
+			expected: map[string]kpis.E2tNodeLifecycle{
+				"node1": {NodeId: "node1"},
+			},
+		},
+		{
+			name: "flapping",
+			collections: []e2Collection{
+				{at: 0, connections: map[string]kpis.E2tConnection{
+					"c1": e2Connection("node1", 100),
+				}},
+				{at: time.Minute, connections: map[string]kpis.E2tConnection{}},
+				{at: 2 * time.Minute, connections: map[string]kpis.E2tConnection{
+					"c2": e2Connection("node1", 10),
+				}},
+				{at: 3 * time.Minute, connections: map[string]kpis.E2tConnection{
+					"c2": e2Connection("node1", 5),
+				}},
+			},
+			expected: map[string]kpis.E2tNodeLifecycle{
+				"node1": {NodeId: "node1", Connects: 2, Disconnects: 2, Flapping: true},
+			},
+		},
+		{
+			name: "flapping events out of window",
+			collections: []e2Collection{
+				{at: 0, connections: map[string]kpis.E2tConnection{
+					"c1": e2Connection("node1", 100),
+				}},
+				{at: time.Minute, connections: map[string]kpis.E2tConnection{}},
+				{at: 2 * time.Minute, connections: map[string]kpis.E2tConnection{
+					"c2": e2Connection("node1", 10),
+				}},
+				{at: 20 * time.Minute, connections: map[string]kpis.E2tConnection{
+					"c2": e2Connection("node1", 5),
+				}},
+			},
+			expected: map[string]kpis.E2tNodeLifecycle{
+				"node1": {NodeId: "node1", Connects: 2, Disconnects: 2},
+			},
+		},
+		{
+			name: "pruning",
+			collections: []e2Collection{
+				{at: 0, connections: map[string]kpis.E2tConnection{
+					"c1": e2Connection("node1", 100),
+					"c2": e2Connection("node2", 100),
+				}},
+				{at: time.Minute, connections: map[string]kpis.E2tConnection{
+					"c2": e2Connection("node2", 160),
+				}},
+				{at: 20 * time.Minute, connections: map[string]kpis.E2tConnection{
+					"c2": e2Connection("node2", 1300),
+				}},
+			},
+			expected: map[string]kpis.E2tNodeLifecycle{
+				"node2": {NodeId: "node2"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := newE2ConnectionTracker(10*time.Minute, 3)
+			start := time.Now()
+			for _, collection := range test.collections {
+				tracker.update(collection.connections, start.Add(collection.at))
+			}
+
+			expected := kpis.OnosE2tNodeLifecycle()
+			expected.Nodes = test.expected
+			assert.Equal(t, expected, tracker.KPI())
+		})
+	}
+}
+
+func TestE2ConnectionTrackerAge(t *testing.T) {
+	tracker := newE2ConnectionTracker(10*time.Minute, 3)
+	start := time.Now()
+	tracker.update(map[string]kpis.E2tConnection{"c1": e2Connection("node1", 0)}, start)
+
+	connections := map[string]kpis.E2tConnection{"c1": e2Connection("node1", 0)}
+	tracker.update(connections, start.Add(time.Minute))
+	assert.Equal(t, 60.0, connections["c1"].Age)
+}
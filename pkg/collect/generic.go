@@ -0,0 +1,762 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	exporterConfig "github.com/onosproject/onos-exporter/pkg/config"
+	"github.com/onosproject/onos-exporter/pkg/kpis"
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	genericConfigKey = "generic.config"
+
+	genericTimeout = 10 * time.Second
+)
+
+var metricNameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// GenericMetricConfig defines how a metric is extracted from the
+// responses of the method called by the generic collector.
+// Path is the dot separated path of the fields whose values are
+// the samples of the metric, repeated fields and maps along the path
+// produce a sample per element (map entries have the fields key and
+// value); if empty, the response is the only sample. A bytes or string
+// value followed by the pseudo field json is decoded as JSON (e.g., the
+// aspects of onos topo and uenib), whose objects are followed by key and
+// whose arrays produce a value per element too.
+// Value is the path, relative to a sample, of its numeric value; if
+// empty, the value is 1 (i.e., an info metric).
+// Labels map label names to the paths, relative to a sample, of their
+// values (if empty, the sample itself). A label path starting with / is
+// relative to the response instead, following the same elements as the
+// sample along the path they share (e.g., /objects.id labels the samples
+// of path objects.aspects with the id of their object). Label names are
+// case sensitive, and can not be the labels added by the exporter (see
+// kpis.StaticLabel and ExporterLabels).
+// Metric names are unique within a GenericConfig. The generic collector
+// instances sharing a subsystem must define the same help, type and labels
+// for the metrics they share, as a metric has a single description in the
+// gather; the help defaults to the description of the generic KPI.
+type GenericMetricConfig struct {
+	Name   string            `json:"name"`
+	Help   string            `json:"help"`
+	Type   string            `json:"type"`
+	Path   string            `json:"path"`
+	Value  string            `json:"value"`
+	Labels map[string]string `json:"labels"`
+}
+
+// GenericConfig defines the declarative configuration of the generic
+// collector, read from the file defined by its option generic.config.
+// Method is the full gRPC method name (e.g., onos.pci.Pci/GetCells),
+// unary or server streaming, called with the JSON encoded Request.
+// DescriptorSets are paths of FileDescriptorSet files (generated with
+// protoc --include_imports) describing the method; if empty, gRPC
+// server reflection is used. Metrics are exported as onos_<subsystem>_<name>,
+// the subsystem not being one of the other collectors (see kpis.ReservedSubsystems).
+type GenericConfig struct {
+	Subsystem      string                `json:"subsystem"`
+	Method         string                `json:"method"`
+	Request        string                `json:"request"`
+	DescriptorSets []string              `json:"descriptorSets"`
+	Metrics        []GenericMetricConfig `json:"metrics"`
+}
+
+// onosGenericCollector is the generic collector of arbitrary gRPC
+// services. It extracts the metrics defined by its GenericConfig
+// using the Collect method.
+type onosGenericCollector struct {
+	collector
+	mu      sync.Mutex
+	generic *GenericConfig
+	method  protoreflect.MethodDescriptor
+}
+
+func init() {
+	MustRegister(CollectorType{
+		Name:        exporterConfig.ONOSGENERIC,
+		Description: "The metrics of any gRPC service (e.g., xapps) defined by a declarative configuration",
+		Options: []OptionSchema{
+			{
+				Key:         genericConfigKey,
+				Description: "path to the YAML/JSON file defining the method called and the metrics extracted",
+			},
+		},
+		Factory: func(base Base) (Collector, error) {
+			return &onosGenericCollector{
+				collector: baseCollector(base),
+			}, nil
+		},
+	})
+}
+
+// Collect implements the Collector interface behavior for
+// onosGenericCollector, returning a list of kpis.KPI.
+func (col *onosGenericCollector) Collect() ([]kpis.KPI, error) {
+	kpis := []kpis.KPI{}
+
+	if len(col.config.getAddress()) == 0 {
+		return kpis, fmt.Errorf("onosGenericCollector Collect missing service address")
+	}
+
+	genericConfig, err := col.loadConfig()
+	if err != nil {
+		return kpis, err
+	}
+
+	conn, err := col.Connect()
+	if err != nil {
+		return kpis, err
+	}
+	defer conn.Close()
+
+	method, err := col.loadMethod(conn, genericConfig)
+	if err != nil {
+		return kpis, err
+	}
+
+	genericKPI, err := listGenericMetrics(conn, genericConfig, method)
+	if err != nil {
+		return kpis, err
+	}
+
+	kpis = append(kpis, genericKPI)
+
+	return kpis, nil
+}
+
+// loadConfig reads and validates the GenericConfig file once,
+// caching it for the following collections.
+func (col *onosGenericCollector) loadConfig() (*GenericConfig, error) {
+	col.mu.Lock()
+	defer col.mu.Unlock()
+
+	if col.generic != nil {
+		return col.generic, nil
+	}
+
+	path := col.Option(genericConfigKey)
+	if path == "" {
+		return nil, fmt.Errorf("onosGenericCollector missing option %s", genericConfigKey)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	genericConfig := &GenericConfig{}
+	if err := yaml.Unmarshal(data, genericConfig); err != nil {
+		return nil, fmt.Errorf("invalid generic collector config %s: %s", path, err)
+	}
+	if err := genericConfig.validate(); err != nil {
+		return nil, fmt.Errorf("invalid generic collector config %s: %s", path, err)
+	}
+
+	col.generic = genericConfig
+	return genericConfig, nil
+}
+
+func (c *GenericConfig) validate() error {
+	if !metricNameRegexp.MatchString(c.Subsystem) {
+		return fmt.Errorf("invalid subsystem %q", c.Subsystem)
+	}
+	for _, subsystem := range kpis.ReservedSubsystems {
+		if c.Subsystem == subsystem {
+			return fmt.Errorf("reserved subsystem %q", c.Subsystem)
+		}
+	}
+	if _, _, err := splitMethod(c.Method); err != nil {
+		return err
+	}
+	if len(c.Metrics) == 0 {
+		return fmt.Errorf("no metrics defined")
+	}
+	names := make(map[string]bool)
+	for _, metric := range c.Metrics {
+		if !metricNameRegexp.MatchString(metric.Name) {
+			return fmt.Errorf("invalid metric name %q", metric.Name)
+		}
+		if names[metric.Name] {
+			return fmt.Errorf("duplicate metric name %q", metric.Name)
+		}
+		names[metric.Name] = true
+		switch metric.Type {
+		case "", kpis.GenericGauge, kpis.GenericCounter:
+		default:
+			return fmt.Errorf("invalid type %q of metric %s", metric.Type, metric.Name)
+		}
+		for label := range metric.Labels {
+			if err := kpis.ValidateLabelName(label, append([]string{kpis.StaticLabel}, ExporterLabels...)...); err != nil {
+				return fmt.Errorf("%s of metric %s", err, metric.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// splitMethod splits a full gRPC method name in the form
+// package.Service/Method into its service and method names.
+func splitMethod(fullMethod string) (string, string, error) {
+	parts := strings.Split(strings.TrimPrefix(fullMethod, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid method %q, expected package.Service/Method", fullMethod)
+	}
+	return parts[0], parts[1], nil
+}
+
+// loadMethod resolves the descriptor of the configured method once,
+// from the descriptor sets or the server reflection, caching it for
+// the following collections.
+func (col *onosGenericCollector) loadMethod(conn *grpc.ClientConn, genericConfig *GenericConfig) (protoreflect.MethodDescriptor, error) {
+	col.mu.Lock()
+	defer col.mu.Unlock()
+
+	if col.method != nil {
+		return col.method, nil
+	}
+
+	serviceName, methodName, err := splitMethod(genericConfig.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	var files *protoregistry.Files
+	if len(genericConfig.DescriptorSets) > 0 {
+		files, err = loadDescriptorSets(genericConfig.DescriptorSets)
+	} else {
+		files, err = reflectDescriptors(conn, serviceName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, err
+	}
+	service, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", serviceName)
+	}
+	method := service.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		return nil, fmt.Errorf("service %s has no method %s", serviceName, methodName)
+	}
+	if method.IsStreamingClient() {
+		return nil, fmt.Errorf("client streaming method %s is not supported", genericConfig.Method)
+	}
+
+	col.method = method
+	return method, nil
+}
+
+// loadDescriptorSets reads the FileDescriptorSet files in paths.
+func loadDescriptorSets(paths []string) (*protoregistry.Files, error) {
+	fileProtos := make(map[string]*descriptorpb.FileDescriptorProto)
+
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		set := &descriptorpb.FileDescriptorSet{}
+		if err := proto.Unmarshal(data, set); err != nil {
+			return nil, fmt.Errorf("invalid descriptor set %s: %s", path, err)
+		}
+		for _, fileProto := range set.File {
+			fileProtos[fileProto.GetName()] = fileProto
+		}
+	}
+
+	return newFiles(fileProtos)
+}
+
+// reflectDescriptors retrieves the file descriptors defining
+// serviceName, and their dependencies, via the gRPC server
+// reflection service.
+func reflectDescriptors(conn *grpc.ClientConn, serviceName string) (*protoregistry.Files, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), genericTimeout)
+	defer cancel()
+
+	client := reflectionpb.NewServerReflectionClient(conn)
+	stream, err := client.ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = stream.CloseSend()
+	}()
+
+	fileProtos := make(map[string]*descriptorpb.FileDescriptorProto)
+
+	request := func(req *reflectionpb.ServerReflectionRequest) error {
+		if err := stream.Send(req); err != nil {
+			return err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return fmt.Errorf("server reflection error: %s", errResp.ErrorMessage)
+		}
+		for _, data := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fileProto := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(data, fileProto); err != nil {
+				return err
+			}
+			fileProtos[fileProto.GetName()] = fileProto
+		}
+		return nil
+	}
+
+	err = request(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{
+			FileContainingSymbol: serviceName,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for missing := missingDependency(fileProtos); missing != ""; missing = missingDependency(fileProtos) {
+		err := request(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{
+				FileByFilename: missing,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := fileProtos[missing]; !ok {
+			return nil, fmt.Errorf("server reflection did not return file %s", missing)
+		}
+	}
+
+	return newFiles(fileProtos)
+}
+
+// missingDependency returns a dependency of the fileProtos not
+// defined by them nor known by the protobuf global registry.
+func missingDependency(fileProtos map[string]*descriptorpb.FileDescriptorProto) string {
+	for _, fileProto := range fileProtos {
+		for _, dep := range fileProto.Dependency {
+			if _, ok := fileProtos[dep]; ok {
+				continue
+			}
+			if _, err := protoregistry.GlobalFiles.FindFileByPath(dep); err == nil {
+				continue
+			}
+			return dep
+		}
+	}
+	return ""
+}
+
+// newFiles creates a registry of the fileProtos, completing their
+// dependencies with the ones known by the protobuf global registry
+// (e.g., google/protobuf well known types).
+func newFiles(fileProtos map[string]*descriptorpb.FileDescriptorProto) (*protoregistry.Files, error) {
+	if missing := missingDependency(fileProtos); missing != "" {
+		return nil, fmt.Errorf("missing file descriptor %s", missing)
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	added := make(map[string]bool)
+	var addGlobal func(path string)
+	addGlobal = func(path string) {
+		if _, ok := fileProtos[path]; ok || added[path] {
+			return
+		}
+		file, err := protoregistry.GlobalFiles.FindFileByPath(path)
+		if err != nil {
+			return
+		}
+		added[path] = true
+		for i := 0; i < file.Imports().Len(); i++ {
+			addGlobal(file.Imports().Get(i).Path())
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+	}
+
+	for _, fileProto := range fileProtos {
+		set.File = append(set.File, fileProto)
+		for _, dep := range fileProto.Dependency {
+			addGlobal(dep)
+		}
+	}
+
+	return protodesc.NewFiles(set)
+}
+
+// listGenericMetrics calls the method of the generic collector
+// configuration, extracting the configured metrics from its
+// responses and storing them according to the data structure
+// of the kpis.GenericMetrics KPI.
+func listGenericMetrics(conn *grpc.ClientConn, genericConfig *GenericConfig, method protoreflect.MethodDescriptor) (kpis.KPI, error) {
+	genericKPI := kpis.GenericMetrics(genericConfig.Subsystem)
+	genericKPI.Samples = make(map[string]kpis.GenericSample)
+
+	request := dynamicpb.NewMessage(method.Input())
+	if genericConfig.Request != "" {
+		if err := protojson.Unmarshal([]byte(genericConfig.Request), request); err != nil {
+			return genericKPI, fmt.Errorf("invalid request of method %s: %s", genericConfig.Method, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), genericTimeout)
+	defer cancel()
+
+	fullMethod := "/" + strings.TrimPrefix(genericConfig.Method, "/")
+
+	if !method.IsStreamingServer() {
+		response := dynamicpb.NewMessage(method.Output())
+		if err := conn.Invoke(ctx, fullMethod, request, response); err != nil {
+			return genericKPI, err
+		}
+		extractGenericSamples(genericKPI.Samples, genericConfig.Metrics, response)
+		return genericKPI, nil
+	}
+
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, fullMethod)
+	if err != nil {
+		return genericKPI, err
+	}
+	if err := stream.SendMsg(request); err != nil {
+		return genericKPI, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return genericKPI, err
+	}
+
+	for {
+		response := dynamicpb.NewMessage(method.Output())
+		err := stream.RecvMsg(response)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return genericKPI, err
+		}
+		extractGenericSamples(genericKPI.Samples, genericConfig.Metrics, response)
+	}
+
+	return genericKPI, nil
+}
+
+// extractGenericSamples adds to samples the metrics extracted from
+// a response message. Samples with the same name and label values
+// are overwritten by the last one extracted.
+func extractGenericSamples(samples map[string]kpis.GenericSample, metrics []GenericMetricConfig, response protoreflect.ProtoMessage) {
+	for _, metric := range metrics {
+		labelNames := make([]string, 0, len(metric.Labels))
+		for label := range metric.Labels {
+			labelNames = append(labelNames, label)
+		}
+		sort.Strings(labelNames)
+
+		root := pathValue{value: protoreflect.ValueOfMessage(response.ProtoReflect())}
+		for _, chain := range fieldChains(root, metric.Path) {
+			element := chain[len(chain)-1]
+			value := 1.0
+			if metric.Value != "" {
+				values := fieldValues(element, metric.Value)
+				if len(values) == 0 {
+					continue
+				}
+				v, ok := numericValue(values[0])
+				if !ok {
+					log.Warnf("generic metric %s value %s is not numeric", metric.Name, metric.Value)
+					continue
+				}
+				value = v
+			}
+
+			labelValues := make([]string, 0, len(labelNames))
+			for _, label := range labelNames {
+				labelValue := ""
+				if values := labelPathValues(chain, metric.Path, metric.Labels[label]); len(values) > 0 {
+					labelValue = stringValue(values[0])
+				}
+				labelValues = append(labelValues, labelValue)
+			}
+
+			sample := kpis.GenericSample{
+				Name:        metric.Name,
+				Help:        metric.Help,
+				Type:        metric.Type,
+				Labels:      labelNames,
+				LabelValues: labelValues,
+				Value:       value,
+			}
+			samples[sample.Key()] = sample
+		}
+	}
+}
+
+// pathValue is a value found following a path of fields, with the
+// descriptor of its field (nil for the response message). Elements
+// of map fields are map entries, whose pseudo fields are key and value.
+// Values decoded from JSON keep the decoded value in json instead.
+type pathValue struct {
+	value  protoreflect.Value
+	field  protoreflect.FieldDescriptor
+	entry  bool
+	key    protoreflect.MapKey
+	isJSON bool
+	json   interface{}
+}
+
+// genericJSONField is the pseudo field decoding a bytes or string value as JSON.
+const genericJSONField = "json"
+
+// fieldValues returns the values found following the dot separated
+// path of fields from value. Repeated fields and maps along the path
+// produce one value per element.
+func fieldValues(value pathValue, path string) []pathValue {
+	chains := fieldChains(value, path)
+	values := make([]pathValue, 0, len(chains))
+	for _, chain := range chains {
+		values = append(values, chain[len(chain)-1])
+	}
+	return values
+}
+
+// fieldChains returns, for each one of the values found following the
+// dot separated path of fields from value, the values it was found
+// through, from value to itself (one per element of the path).
+func fieldChains(value pathValue, path string) [][]pathValue {
+	chains := [][]pathValue{{value}}
+	if path == "" {
+		return chains
+	}
+
+	for _, name := range strings.Split(path, ".") {
+		next := [][]pathValue{}
+		for _, chain := range chains {
+			for _, child := range childValues(chain[len(chain)-1], name) {
+				next = append(next, append(append([]pathValue{}, chain...), child))
+			}
+		}
+		chains = next
+	}
+	return chains
+}
+
+// labelPathValues returns the values of the label path of the sample found
+// through chain following samplePath. A label path starting with / is
+// followed from the element of chain at the end of the path it shares
+// with samplePath.
+func labelPathValues(chain []pathValue, samplePath, labelPath string) []pathValue {
+	if !strings.HasPrefix(labelPath, "/") {
+		return fieldValues(chain[len(chain)-1], labelPath)
+	}
+
+	labelNames := strings.Split(strings.TrimPrefix(labelPath, "/"), ".")
+	sampleNames := []string{}
+	if samplePath != "" {
+		sampleNames = strings.Split(samplePath, ".")
+	}
+	shared := 0
+	for shared < len(labelNames) && shared < len(sampleNames) && labelNames[shared] == sampleNames[shared] {
+		shared++
+	}
+	return fieldValues(chain[shared], strings.Join(labelNames[shared:], "."))
+}
+
+// jsonValues returns the values of a decoded JSON value,
+// flattening arrays.
+func jsonValues(decoded interface{}) []pathValue {
+	if array, ok := decoded.([]interface{}); ok {
+		values := make([]pathValue, 0, len(array))
+		for _, element := range array {
+			values = append(values, pathValue{isJSON: true, json: element})
+		}
+		return values
+	}
+	return []pathValue{{isJSON: true, json: decoded}}
+}
+
+// childValues returns the values of the field name of value,
+// flattening repeated fields and maps.
+func childValues(value pathValue, name string) []pathValue {
+	if value.isJSON {
+		object, ok := value.json.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		child, ok := object[name]
+		if !ok {
+			return nil
+		}
+		return jsonValues(child)
+	}
+
+	if name == genericJSONField && value.field != nil && !value.entry {
+		var data []byte
+		switch v := value.value.Interface().(type) {
+		case []byte:
+			data = v
+		case string:
+			data = []byte(v)
+		}
+		if data != nil {
+			var decoded interface{}
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				return nil
+			}
+			return jsonValues(decoded)
+		}
+	}
+
+	if value.entry {
+		switch name {
+		case "key":
+			return []pathValue{{value: value.key.Value(), field: value.field.MapKey()}}
+		case "value":
+			return []pathValue{{value: value.value, field: value.field.MapValue()}}
+		default:
+			return childValues(pathValue{value: value.value, field: value.field.MapValue()}, name)
+		}
+	}
+
+	if value.field != nil && value.field.Message() == nil {
+		return nil
+	}
+	msg := value.value.Message()
+
+	field := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if field == nil {
+		field = msg.Descriptor().Fields().ByJSONName(name)
+	}
+	if field == nil || (!field.IsList() && !field.IsMap() && field.Message() != nil && !msg.Has(field)) {
+		return nil
+	}
+
+	fieldValue := msg.Get(field)
+	switch {
+	case field.IsList():
+		values := []pathValue{}
+		list := fieldValue.List()
+		for i := 0; i < list.Len(); i++ {
+			values = append(values, pathValue{value: list.Get(i), field: field})
+		}
+		return values
+	case field.IsMap():
+		values := []pathValue{}
+		fieldValue.Map().Range(func(key protoreflect.MapKey, v protoreflect.Value) bool {
+			values = append(values, pathValue{value: v, field: field, entry: true, key: key})
+			return true
+		})
+		return values
+	default:
+		return []pathValue{{value: fieldValue, field: field}}
+	}
+}
+
+// numericValue converts a scalar value to float64.
+func numericValue(value pathValue) (float64, bool) {
+	if value.isJSON {
+		switch v := value.json.(type) {
+		case float64:
+			return v, true
+		case bool:
+			if v {
+				return 1, true
+			}
+			return 0, true
+		default:
+			return 0, false
+		}
+	}
+
+	switch v := value.value.Interface().(type) {
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case protoreflect.EnumNumber:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// stringValue formats a value as a label value.
+func stringValue(value pathValue) string {
+	if value.isJSON {
+		switch v := value.json.(type) {
+		case nil:
+			return ""
+		case string:
+			return v
+		case float64:
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		case bool:
+			return strconv.FormatBool(v)
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				return ""
+			}
+			return string(data)
+		}
+	}
+
+	switch v := value.value.Interface().(type) {
+	case []byte:
+		return fmt.Sprintf("%x", v)
+	case protoreflect.EnumNumber:
+		if value.field != nil && value.field.Enum() != nil {
+			if enumValue := value.field.Enum().Values().ByNumber(v); enumValue != nil {
+				return string(enumValue.Name())
+			}
+		}
+		return fmt.Sprintf("%d", v)
+	case protoreflect.Message:
+		data, err := protojson.Marshal(v.Interface())
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	default:
+		return value.value.String()
+	}
+}
@@ -0,0 +1,324 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	gogotypes "github.com/gogo/protobuf/types"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-exporter/pkg/kpis"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoimpl"
+	"sigs.k8s.io/yaml"
+)
+
+// genericResponse returns a topo list response, as a response of
+// the method called by the generic collector.
+func genericResponse() protoreflect.ProtoMessage {
+	return protoimpl.X.ProtoMessageV2Of(&topoapi.ListResponse{
+		Objects: []topoapi.Object{
+			{
+				ID:   "e2:1",
+				Type: topoapi.Object_ENTITY,
+				Obj:  &topoapi.Object_Entity{Entity: &topoapi.Entity{KindID: "e2node"}},
+				Labels: map[string]string{
+					"site": "a",
+					"tier": "edge",
+				},
+				Aspects: map[string]*gogotypes.Any{
+					"onos.topo.RSMSliceItemList": {
+						TypeUrl: "onos.topo.RSMSliceItemList",
+						Value:   []byte(`{"rsmSliceList": [{"id": "1", "sliceDesc": {"weight": 30}}, {"id": "2", "sliceType": "SLICE_TYPE_UL_SLICE"}]}`),
+					},
+					"onos.topo.Location": {
+						TypeUrl: "onos.topo.Location",
+						Value:   []byte(`not json`),
+					},
+				},
+			},
+			{
+				ID:   "e2:1-e2:1/1",
+				Type: topoapi.Object_RELATION,
+				Obj:  &topoapi.Object_Relation{Relation: &topoapi.Relation{KindID: "contains", SrcEntityID: "e2:1", TgtEntityID: "e2:1/1"}},
+			},
+		},
+	})
+}
+
+func TestGenericFieldValues(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{
+			path:     "objects.id",
+			expected: []string{"e2:1", "e2:1-e2:1/1"},
+		},
+		{
+			path:     "objects.type",
+			expected: []string{"ENTITY", "RELATION"},
+		},
+		{
+			path:     "objects.entity.kind_id",
+			expected: []string{"e2node"},
+		},
+		{
+			path:     "objects.relation.srcEntityId",
+			expected: []string{"e2:1"},
+		},
+		{
+			path:     "objects.labels.key",
+			expected: []string{"site", "tier"},
+		},
+		{
+			path:     "objects.labels.value",
+			expected: []string{"a", "edge"},
+		},
+		{
+			path:     "objects.aspects.key",
+			expected: []string{"onos.topo.Location", "onos.topo.RSMSliceItemList"},
+		},
+		{
+			path:     "objects.aspects.typeUrl",
+			expected: []string{"onos.topo.Location", "onos.topo.RSMSliceItemList"},
+		},
+		{
+			path:     "objects.aspects.value.value.json.rsmSliceList.id",
+			expected: []string{"1", "2"},
+		},
+		{
+			path:     "objects.aspects.value.value.json.rsmSliceList.sliceDesc.weight",
+			expected: []string{"30"},
+		},
+		{
+			path:     "objects.aspects.value.value.json.rsmSliceList.sliceDesc",
+			expected: []string{`{"weight":30}`},
+		},
+		{
+			path:     "objects.id.json",
+			expected: []string{},
+		},
+		{
+			path:     "objects.unknown",
+			expected: []string{},
+		},
+		{
+			path:     "objects.id.unknown",
+			expected: []string{},
+		},
+	}
+
+	root := pathValue{value: protoreflect.ValueOfMessage(genericResponse().ProtoReflect())}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			values := []string{}
+			for _, value := range fieldValues(root, test.path) {
+				values = append(values, stringValue(value))
+			}
+			sort.Strings(values)
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestExtractGenericSamples(t *testing.T) {
+	tests := []struct {
+		name     string
+		metric   GenericMetricConfig
+		expected []kpis.GenericSample
+	}{
+		{
+			name:   "response",
+			metric: GenericMetricConfig{Name: "objects"},
+			expected: []kpis.GenericSample{
+				{Name: "objects", Labels: []string{}, LabelValues: []string{}, Value: 1},
+			},
+		},
+		{
+			name: "map entries",
+			metric: GenericMetricConfig{
+				Name:   "labels",
+				Path:   "objects.labels",
+				Labels: map[string]string{"key": "key", "value": "value", "id": "/objects.id"},
+			},
+			expected: []kpis.GenericSample{
+				{Name: "labels", Labels: []string{"id", "key", "value"}, LabelValues: []string{"e2:1", "site", "a"}, Value: 1},
+				{Name: "labels", Labels: []string{"id", "key", "value"}, LabelValues: []string{"e2:1", "tier", "edge"}, Value: 1},
+			},
+		},
+		{
+			name: "json values",
+			metric: GenericMetricConfig{
+				Name:   "slice_weight",
+				Type:   kpis.GenericGauge,
+				Path:   "objects.aspects.value.value.json.rsmSliceList",
+				Value:  "sliceDesc.weight",
+				Labels: map[string]string{"e2node_id": "/objects.id", "aspect": "/objects.aspects.key", "slice_id": "id"},
+			},
+			expected: []kpis.GenericSample{
+				{Name: "slice_weight", Type: kpis.GenericGauge, Labels: []string{"aspect", "e2node_id", "slice_id"}, LabelValues: []string{"onos.topo.RSMSliceItemList", "e2:1", "1"}, Value: 30},
+			},
+		},
+		{
+			name: "missing labels",
+			metric: GenericMetricConfig{
+				Name:   "slice_info",
+				Path:   "objects.aspects.value.value.json.rsmSliceList",
+				Labels: map[string]string{"slice_id": "id", "slice_type": "sliceType"},
+			},
+			expected: []kpis.GenericSample{
+				{Name: "slice_info", Labels: []string{"slice_id", "slice_type"}, LabelValues: []string{"1", ""}, Value: 1},
+				{Name: "slice_info", Labels: []string{"slice_id", "slice_type"}, LabelValues: []string{"2", "SLICE_TYPE_UL_SLICE"}, Value: 1},
+			},
+		},
+		{
+			name: "enum values",
+			metric: GenericMetricConfig{
+				Name:   "object_type",
+				Path:   "objects",
+				Value:  "type",
+				Labels: map[string]string{"id": "id"},
+			},
+			expected: []kpis.GenericSample{
+				{Name: "object_type", Labels: []string{"id"}, LabelValues: []string{"e2:1-e2:1/1"}, Value: float64(topoapi.Object_RELATION)},
+				{Name: "object_type", Labels: []string{"id"}, LabelValues: []string{"e2:1"}, Value: float64(topoapi.Object_ENTITY)},
+			},
+		},
+		{
+			name: "values not numeric",
+			metric: GenericMetricConfig{
+				Name:  "ids",
+				Path:  "objects",
+				Value: "id",
+			},
+			expected: []kpis.GenericSample{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			samples := make(map[string]kpis.GenericSample)
+			extractGenericSamples(samples, []GenericMetricConfig{test.metric}, genericResponse())
+
+			values := []kpis.GenericSample{}
+			for _, sample := range samples {
+				values = append(values, sample)
+			}
+			sort.Slice(values, func(i, j int) bool {
+				return values[i].Key() < values[j].Key()
+			})
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestGenericConfigValidate(t *testing.T) {
+	valid := func() GenericConfig {
+		return GenericConfig{
+			Subsystem: "rsm",
+			Method:    "onos.topo.Topo/List",
+			Metrics: []GenericMetricConfig{
+				{Name: "slice_info", Labels: map[string]string{"slice_id": "id"}},
+				{Name: "slice_weight", Type: kpis.GenericGauge, Value: "weight"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *GenericConfig)
+		err    bool
+	}{
+		{
+			name:   "valid",
+			modify: func(c *GenericConfig) {},
+		},
+		{
+			name:   "invalid subsystem",
+			modify: func(c *GenericConfig) { c.Subsystem = "onos-rsm" },
+			err:    true,
+		},
+		{
+			name:   "reserved subsystem",
+			modify: func(c *GenericConfig) { c.Subsystem = "topo" },
+			err:    true,
+		},
+		{
+			name:   "invalid method",
+			modify: func(c *GenericConfig) { c.Method = "onos.topo.Topo.List" },
+			err:    true,
+		},
+		{
+			name:   "no metrics",
+			modify: func(c *GenericConfig) { c.Metrics = nil },
+			err:    true,
+		},
+		{
+			name:   "invalid metric name",
+			modify: func(c *GenericConfig) { c.Metrics[0].Name = "slice-info" },
+			err:    true,
+		},
+		{
+			name:   "duplicate metric name",
+			modify: func(c *GenericConfig) { c.Metrics[1].Name = "slice_info" },
+			err:    true,
+		},
+		{
+			name:   "invalid type",
+			modify: func(c *GenericConfig) { c.Metrics[0].Type = "histogram" },
+			err:    true,
+		},
+		{
+			name:   "invalid label name",
+			modify: func(c *GenericConfig) { c.Metrics[0].Labels["slice-id"] = "id" },
+			err:    true,
+		},
+		{
+			name:   "reserved label name",
+			modify: func(c *GenericConfig) { c.Metrics[0].Labels[InstanceLabel] = "id" },
+			err:    true,
+		},
+		{
+			name:   "static label name",
+			modify: func(c *GenericConfig) { c.Metrics[0].Labels[kpis.StaticLabel] = "id" },
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := valid()
+			test.modify(&c)
+			err := c.validate()
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGenericExamples(t *testing.T) {
+	paths, err := filepath.Glob("../../examples/generic/*.yaml")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := ioutil.ReadFile(path)
+			assert.NoError(t, err)
+
+			genericConfig := &GenericConfig{}
+			assert.NoError(t, yaml.Unmarshal(data, genericConfig))
+			assert.NoError(t, genericConfig.validate())
+		})
+	}
+}
@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	adminapi "github.com/onosproject/onos-api/go/onos/config/admin"
+	devicechange "github.com/onosproject/onos-api/go/onos/config/change/device"
+	diagsapi "github.com/onosproject/onos-api/go/onos/config/diags"
+	exporterConfig "github.com/onosproject/onos-exporter/pkg/config"
+	"github.com/onosproject/onos-exporter/pkg/kpis"
+	"google.golang.org/grpc"
+)
+
+// onosConfigTimeout bounds all the listings of a collection of onos config.
+const onosConfigTimeout = 10 * time.Second
+
+// onosConfigCollector is the onos config collector.
+// It extracts all the onos config related kpis using the Collect method.
+// The targets are the ones changed by the network changes, onos config
+// does not list its configured targets nor their connection state. Those
+// are kept by the onos topo entities of the targets, whose Protocols
+// aspect is exported by the onos topo collector (onos_topo_aspect_protocol_*).
+type onosConfigCollector struct {
+	collector
+}
+
+func init() {
+	MustRegister(CollectorType{
+		Name:        exporterConfig.ONOSCONFIG,
+		Description: "The onos config model plugins, network changes and device changes of the changed targets (the target connection states are exported by onos-topo)",
+		Factory: func(base Base) (Collector, error) {
+			return &onosConfigCollector{
+				collector: baseCollector(base),
+			}, nil
+		},
+	})
+}
+
+// Collect implements the Collector interface behavior for
+// onosConfigCollector, returning a list of kpis.KPI.
+func (col *onosConfigCollector) Collect() ([]kpis.KPI, error) {
+	kpis := []kpis.KPI{}
+
+	if len(col.config.getAddress()) == 0 {
+		return kpis, fmt.Errorf("onosConfigCollector Collect missing service address")
+	}
+
+	conn, err := col.Connect()
+	if err != nil {
+		return kpis, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), onosConfigTimeout)
+	defer cancel()
+
+	modelsKPI, err := listConfigModels(ctx, conn)
+	if err != nil {
+		return kpis, err
+	}
+
+	networkChangesKPI, targets, err := listConfigNetworkChanges(ctx, conn)
+	if err != nil {
+		return kpis, err
+	}
+
+	deviceChangesKPI, err := listConfigDeviceChanges(ctx, conn, targets)
+	if err != nil {
+		return kpis, err
+	}
+
+	kpis = append(kpis, modelsKPI)
+	kpis = append(kpis, networkChangesKPI)
+	kpis = append(kpis, deviceChangesKPI)
+
+	return kpis, err
+}
+
+// listConfigModels receives a connection to a onos config service
+// to retrieve its registered model plugins and store them according
+// to the data structure of the kpis.OnosConfigModels KPI.
+func listConfigModels(ctx context.Context, conn *grpc.ClientConn) (kpis.KPI, error) {
+	modelsKPI := kpis.OnosConfigModels()
+	modelsKPI.Models = make(map[string]kpis.ConfigModel)
+
+	client := adminapi.NewConfigAdminServiceClient(conn)
+
+	stream, err := client.ListRegisteredModels(ctx, &adminapi.ListModelsRequest{})
+	if err != nil {
+		return modelsKPI, err
+	}
+
+	for {
+		model, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return modelsKPI, err
+		}
+		modelsKPI.Models[model.Name+":"+model.Version] = kpis.ConfigModel{
+			Name:    model.Name,
+			Version: model.Version,
+			Module:  model.Module,
+		}
+	}
+
+	return modelsKPI, nil
+}
+
+// listConfigNetworkChanges receives a connection to a onos config service
+// to retrieve its network changes and store them according to the data
+// structure of the kpis.OnosConfigNetworkChanges KPI. It returns the
+// targets changed by them too, as device IDs and versions.
+func listConfigNetworkChanges(ctx context.Context, conn *grpc.ClientConn) (kpis.KPI, []*devicechange.Change, error) {
+	networkChangesKPI := kpis.OnosConfigNetworkChanges()
+	networkChangesKPI.NetworkChanges = make(map[string]kpis.ConfigNetworkChange)
+	targets := []*devicechange.Change{}
+
+	client := diagsapi.NewChangeServiceClient(conn)
+
+	stream, err := client.ListNetworkChanges(ctx, &diagsapi.ListNetworkChangeRequest{})
+	if err != nil {
+		return networkChangesKPI, targets, err
+	}
+
+	seen := make(map[string]bool)
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return networkChangesKPI, targets, err
+		}
+
+		change := response.GetChange()
+		if change == nil || change.Deleted {
+			continue
+		}
+		networkChangesKPI.NetworkChanges[string(change.ID)] = kpis.ConfigNetworkChange{
+			ID:    string(change.ID),
+			Phase: strings.ToLower(change.Status.Phase.String()),
+			State: strings.ToLower(change.Status.State.String()),
+		}
+
+		for _, deviceChange := range change.Changes {
+			target := string(deviceChange.DeviceID) + ":" + string(deviceChange.DeviceVersion)
+			if !seen[target] {
+				seen[target] = true
+				targets = append(targets, deviceChange)
+			}
+		}
+	}
+
+	return networkChangesKPI, targets, nil
+}
+
+// listConfigDeviceChanges receives a connection to a onos config service
+// to retrieve the device changes of each one of the targets and store
+// them according to the data structure of the kpis.OnosConfigDeviceChanges KPI.
+func listConfigDeviceChanges(ctx context.Context, conn *grpc.ClientConn, targets []*devicechange.Change) (kpis.KPI, error) {
+	deviceChangesKPI := kpis.OnosConfigDeviceChanges()
+	deviceChangesKPI.DeviceChanges = make(map[string]kpis.ConfigDeviceChange)
+
+	client := diagsapi.NewChangeServiceClient(conn)
+
+	for _, target := range targets {
+		err := listTargetDeviceChanges(ctx, client, target, deviceChangesKPI.DeviceChanges)
+		if err != nil {
+			return deviceChangesKPI, err
+		}
+	}
+
+	return deviceChangesKPI, nil
+}
+
+// listTargetDeviceChanges lists the device changes of a target, adding
+// them to deviceChanges. The device type of the changes missing it is
+// the one of the target.
+func listTargetDeviceChanges(ctx context.Context, client diagsapi.ChangeServiceClient, target *devicechange.Change, deviceChanges map[string]kpis.ConfigDeviceChange) error {
+	stream, err := client.ListDeviceChanges(ctx, &diagsapi.ListDeviceChangeRequest{
+		DeviceID:      target.DeviceID,
+		DeviceVersion: target.DeviceVersion,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		change := response.GetChange()
+		if change == nil {
+			continue
+		}
+		deviceType := string(target.DeviceType)
+		if change.Change != nil && change.Change.DeviceType != "" {
+			deviceType = string(change.Change.DeviceType)
+		}
+		deviceChanges[string(change.ID)] = kpis.ConfigDeviceChange{
+			ID:            string(change.ID),
+			DeviceID:      string(target.DeviceID),
+			DeviceType:    deviceType,
+			DeviceVersion: string(target.DeviceVersion),
+			Phase:         strings.ToLower(change.Status.Phase.String()),
+			State:         strings.ToLower(change.Status.State.String()),
+		}
+	}
+}
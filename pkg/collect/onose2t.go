@@ -8,17 +8,88 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
+	"strconv"
 	"strings"
+	"time"
 
 	adminapi "github.com/onosproject/onos-api/go/onos/e2t/admin"
+	e2api "github.com/onosproject/onos-api/go/onos/e2t/e2/v1beta1"
+	exporterConfig "github.com/onosproject/onos-exporter/pkg/config"
 	"github.com/onosproject/onos-exporter/pkg/kpis"
 	"google.golang.org/grpc"
 )
 
 // onose2tCollector is the onos e2t collector.
 // It extracts all the e2t related kpis using the Collect method.
+// The connections tracker keeps the e2 connections across collections,
+// the subnet masks define the subnets the connections are counted by.
 type onose2tCollector struct {
 	collector
+	connections *e2ConnectionTracker
+	subnets     e2SubnetMasks
+}
+
+// Consts define the options of the onos e2t collector.
+const (
+	e2tFlapWindowKey    = "e2t.flap-window"
+	e2tFlapThresholdKey = "e2t.flap-threshold"
+	e2tIPv4PrefixKey    = "e2t.subnet-ipv4-prefix"
+	e2tIPv6PrefixKey    = "e2t.subnet-ipv6-prefix"
+)
+
+func init() {
+	MustRegister(CollectorType{
+		Name:        exporterConfig.ONOSE2T,
+		Description: "The onos e2t connections, service models, subscriptions and channels",
+		Options: []OptionSchema{
+			{
+				Key:         e2tFlapWindowKey,
+				Description: "window in which the connects and disconnects of an e2 node are counted to flag it as flapping",
+				Default:     "10m",
+			},
+			{
+				Key:         e2tFlapThresholdKey,
+				Description: "number of connects and disconnects of an e2 node within the flap window flagging it as flapping",
+				Default:     "3",
+			},
+			{
+				Key:         e2tIPv4PrefixKey,
+				Description: "prefix length of the IPv4 subnets the e2 connections are counted by",
+				Default:     "24",
+			},
+			{
+				Key:         e2tIPv6PrefixKey,
+				Description: "prefix length of the IPv6 subnets the e2 connections are counted by",
+				Default:     "64",
+			},
+		},
+		Factory: func(base Base) (Collector, error) {
+			window, err := time.ParseDuration(base.Option(e2tFlapWindowKey))
+			if err == nil && window <= 0 {
+				err = fmt.Errorf("window %s must be positive", window)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid option %s: %s", e2tFlapWindowKey, err)
+			}
+			threshold, err := strconv.Atoi(base.Option(e2tFlapThresholdKey))
+			if err == nil && threshold < 1 {
+				err = fmt.Errorf("threshold %d must be at least 1", threshold)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid option %s: %s", e2tFlapThresholdKey, err)
+			}
+			subnets, err := newE2SubnetMasks(base.Option(e2tIPv4PrefixKey), base.Option(e2tIPv6PrefixKey))
+			if err != nil {
+				return nil, err
+			}
+			return &onose2tCollector{
+				collector:   baseCollector(base),
+				connections: newE2ConnectionTracker(window, threshold),
+				subnets:     subnets,
+			}, nil
+		},
+	})
 }
 
 // Collect implements the collector of the onos e2t service kpis.
@@ -34,33 +105,54 @@ func (col *onose2tCollector) Collect() ([]kpis.KPI, error) {
 		return kpis, fmt.Errorf("Onose2tCollector Collect missing service address")
 	}
 
-	conn, err := GetConnection(
-		col.config.getAddress(),
-		col.config.getCertPath(),
-		col.config.getKeyPath(),
-		col.config.noTLS(),
-	)
+	conn, err := col.Connect()
 	if err != nil {
 		return kpis, err
 	}
 	defer conn.Close()
 
-	e2tconnectionsKPI, err := onose2tListConnections(conn)
+	e2tconnectionsKPI, err := onose2tListConnections(conn, col.connections, col.subnets)
 	if err != nil {
 		return kpis, err
 	}
 
-	kpis = append(kpis, e2tconnectionsKPI)
+	kpis = append(kpis, e2tconnectionsKPI, col.connections.KPI())
+
+	// Service models, subscriptions and channels are listed by other e2t
+	// admin calls, failures are logged to keep exporting the connections
+	// of e2t instances not providing them.
+	e2tServiceModelsKPI, err := onose2tListServiceModels(conn)
+	if err != nil {
+		log.Warnf("Onose2tCollector list service models error %s", err)
+	} else {
+		kpis = append(kpis, e2tServiceModelsKPI)
+	}
+
+	e2tSubscriptionsKPI, err := onose2tListSubscriptions(conn)
+	if err != nil {
+		log.Warnf("Onose2tCollector list subscriptions error %s", err)
+	} else {
+		kpis = append(kpis, e2tSubscriptionsKPI)
+	}
+
+	e2tChannelsKPI, err := onose2tListChannels(conn)
+	if err != nil {
+		log.Warnf("Onose2tCollector list channels error %s", err)
+	} else {
+		kpis = append(kpis, e2tChannelsKPI)
+	}
 
 	return kpis, nil
 }
 
 // onose2tListConnections implements the extraction of the kpi OnosE2tConnections
 // from the component onose2t. It connects to onos e2t service list the e2NodeConnections
-// and fill the proper fields of the OnosE2tConnectionsKPI.
+// and fill the proper fields of the OnosE2tConnectionsKPI. The connections are
+// recorded by the tracker, which sets the age of those not reporting it, and
+// their remote IPs are masked to the subnets they are counted by.
 // Other functions must be implemented similar to this one in order to extract other
 // kpis from onos e2t service.
-func onose2tListConnections(conn *grpc.ClientConn) (kpis.KPI, error) {
+func onose2tListConnections(conn *grpc.ClientConn, tracker *e2ConnectionTracker, subnets e2SubnetMasks) (kpis.KPI, error) {
 	OnosE2tConnectionsKPI := kpis.OnosE2tConnections()
 	OnosE2tConnectionsKPI.NumberConnections = make(map[string]kpis.E2tConnection)
 
@@ -87,8 +179,169 @@ func onose2tListConnections(conn *grpc.ClientConn) (kpis.KPI, error) {
 			RemoteIp:       strings.Join(response.RemoteIp, ","),
 			RemotePort:     fmt.Sprintf("%v", response.RemotePort),
 			ConnectionType: response.ConnectionType.String(),
+			RanFunctions:   ranFunctionOIDs(response.RanFunctions),
+			Age:            float64(response.AgeMs) / 1000,
+			RemoteSubnets:  subnets.subnets(response.RemoteIp),
 		}
 	}
 
+	tracker.update(OnosE2tConnectionsKPI.NumberConnections, time.Now())
+
 	return OnosE2tConnectionsKPI, nil
 }
+
+// e2SubnetMasks defines the masks of the IPv4 and IPv6 subnets
+// the e2 connections are counted by.
+type e2SubnetMasks struct {
+	ipv4 net.IPMask
+	ipv6 net.IPMask
+}
+
+func newE2SubnetMasks(ipv4Prefix, ipv6Prefix string) (e2SubnetMasks, error) {
+	ipv4, err := strconv.Atoi(ipv4Prefix)
+	if err != nil || ipv4 < 0 || ipv4 > 32 {
+		return e2SubnetMasks{}, fmt.Errorf("invalid option %s: %s", e2tIPv4PrefixKey, ipv4Prefix)
+	}
+	ipv6, err := strconv.Atoi(ipv6Prefix)
+	if err != nil || ipv6 < 0 || ipv6 > 128 {
+		return e2SubnetMasks{}, fmt.Errorf("invalid option %s: %s", e2tIPv6PrefixKey, ipv6Prefix)
+	}
+	return e2SubnetMasks{
+		ipv4: net.CIDRMask(ipv4, 32),
+		ipv6: net.CIDRMask(ipv6, 128),
+	}, nil
+}
+
+// subnets returns the distinct subnets of the remote IPs of an
+// e2 node connection, keeping the IPs that can not be parsed.
+func (m e2SubnetMasks) subnets(ips []string) []string {
+	subnets := []string{}
+	seen := make(map[string]bool)
+	for _, ip := range ips {
+		subnet := ip
+		if parsed := net.ParseIP(ip); parsed != nil {
+			mask := m.ipv6
+			if ipv4 := parsed.To4(); ipv4 != nil {
+				parsed, mask = ipv4, m.ipv4
+			}
+			ones, _ := mask.Size()
+			subnet = fmt.Sprintf("%s/%d", parsed.Mask(mask), ones)
+		}
+		if !seen[subnet] {
+			seen[subnet] = true
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets
+}
+
+// ranFunctionOIDs returns the OIDs of the RAN functions
+// of an e2 node connection.
+func ranFunctionOIDs(ranFunctions []*adminapi.RANFunction) []string {
+	oids := []string{}
+	for _, ranFunction := range ranFunctions {
+		if ranFunction != nil && ranFunction.Oid != "" {
+			oids = append(oids, ranFunction.Oid)
+		}
+	}
+	return oids
+}
+
+// onose2tListServiceModels implements the extraction of the kpi OnosE2tServiceModels
+// from the component onose2t. It connects to onos e2t admin service, lists the
+// registered service model plugins and fill the proper fields of the
+// OnosE2tServiceModelsKPI. The admin service does not report the OID of the
+// plugins, those are exported by the RAN functions of the connections.
+func onose2tListServiceModels(conn *grpc.ClientConn) (kpis.KPI, error) {
+	OnosE2tServiceModelsKPI := kpis.OnosE2tServiceModels()
+	OnosE2tServiceModelsKPI.ServiceModels = make(map[string]kpis.E2tServiceModel)
+
+	request := adminapi.ListRegisteredServiceModelsRequest{}
+	client := adminapi.NewE2TAdminServiceClient(conn)
+	stream, err := client.ListRegisteredServiceModels(context.Background(), &request)
+	if err != nil {
+		return OnosE2tServiceModelsKPI, err
+	}
+
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return OnosE2tServiceModelsKPI, err
+		}
+
+		key := fmt.Sprintf("%s:%s", response.Name, response.Version)
+		OnosE2tServiceModelsKPI.ServiceModels[key] = kpis.E2tServiceModel{
+			Name:    response.Name,
+			Version: response.Version,
+		}
+	}
+
+	return OnosE2tServiceModelsKPI, nil
+}
+
+// onose2tListSubscriptions implements the extraction of the kpi OnosE2tSubscriptions
+// from the component onose2t. It connects to onos e2t subscription admin service,
+// lists the E2 subscriptions and fill the proper fields of the OnosE2tSubscriptionsKPI.
+func onose2tListSubscriptions(conn *grpc.ClientConn) (kpis.KPI, error) {
+	OnosE2tSubscriptionsKPI := kpis.OnosE2tSubscriptions()
+	OnosE2tSubscriptionsKPI.Subscriptions = make(map[string]kpis.E2tSubscription)
+
+	request := e2api.ListSubscriptionsRequest{}
+	client := e2api.NewSubscriptionAdminServiceClient(conn)
+	response, err := client.ListSubscriptions(context.Background(), &request)
+	if err != nil {
+		return OnosE2tSubscriptionsKPI, err
+	}
+
+	for _, sub := range response.Subscriptions {
+		OnosE2tSubscriptionsKPI.Subscriptions[string(sub.ID)] = kpis.E2tSubscription{
+			ID:                  string(sub.ID),
+			NodeID:              string(sub.E2NodeID),
+			ServiceModel:        string(sub.ServiceModel.Name),
+			ServiceModelVersion: string(sub.ServiceModel.Version),
+			Phase:               lifecycleName(sub.Status.Phase.String(), "SUBSCRIPTION_"),
+			State:               lifecycleName(sub.Status.State.String(), "SUBSCRIPTION_"),
+		}
+	}
+
+	return OnosE2tSubscriptionsKPI, nil
+}
+
+// onose2tListChannels implements the extraction of the kpi OnosE2tChannels
+// from the component onose2t. It connects to onos e2t subscription admin service,
+// lists the E2 channels of the xapps and fill the proper fields of the
+// OnosE2tChannelsKPI.
+func onose2tListChannels(conn *grpc.ClientConn) (kpis.KPI, error) {
+	OnosE2tChannelsKPI := kpis.OnosE2tChannels()
+	OnosE2tChannelsKPI.Channels = make(map[string]kpis.E2tChannel)
+
+	request := e2api.ListChannelsRequest{}
+	client := e2api.NewSubscriptionAdminServiceClient(conn)
+	response, err := client.ListChannels(context.Background(), &request)
+	if err != nil {
+		return OnosE2tChannelsKPI, err
+	}
+
+	for _, channel := range response.Channels {
+		OnosE2tChannelsKPI.Channels[string(channel.ID)] = kpis.E2tChannel{
+			ID:                  string(channel.ID),
+			AppID:               string(channel.AppID),
+			NodeID:              string(channel.E2NodeID),
+			ServiceModel:        string(channel.ServiceModel.Name),
+			ServiceModelVersion: string(channel.ServiceModel.Version),
+			Phase:               lifecycleName(channel.Status.Phase.String(), "CHANNEL_"),
+			State:               lifecycleName(channel.Status.State.String(), "CHANNEL_"),
+		}
+	}
+
+	return OnosE2tChannelsKPI, nil
+}
+
+// lifecycleName returns the lower case name of a subscription or channel
+// phase/state enum value without its prefix (e.g., SUBSCRIPTION_PENDING
+// is pending).
+func lifecycleName(value, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(value, prefix))
+}
@@ -0,0 +1,310 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	exporterConfig "github.com/onosproject/onos-exporter/pkg/config"
+	"github.com/onosproject/onos-exporter/pkg/kpis"
+	"google.golang.org/grpc"
+)
+
+// onosRansimTimeout bounds each of the listings of the ransim model.
+const onosRansimTimeout = 10 * time.Second
+
+// ransimUEMetricsKey defines the option enabling the per UE metrics,
+// whose number of series grows with the number of simulated UEs.
+const ransimUEMetricsKey = "ransim.ue-metrics"
+
+// onosRansimCollector is the RAN simulator collector, meant for lab
+// environments. It extracts the ground truth of the simulation (nodes,
+// cells, UEs and routes) from the ransim model using the Collect method.
+// The IDs of nodes and cells are hexadecimal, as the cell IDs of the pci
+// xapp collector. The ueMetrics enable the per UE and route metrics.
+type onosRansimCollector struct {
+	collector
+	ueMetrics bool
+}
+
+func init() {
+	MustRegister(CollectorType{
+		Name:        exporterConfig.ONOSRANSIM,
+		Description: "The ransim simulated nodes, cells, UEs and routes",
+		Options: []OptionSchema{
+			{
+				Key:         ransimUEMetricsKey,
+				Description: "export the serving cell, RSRP and position of each UE and the progress of its route",
+				Default:     "true",
+			},
+		},
+		Factory: func(base Base) (Collector, error) {
+			ueMetrics, err := strconv.ParseBool(base.Option(ransimUEMetricsKey))
+			if err != nil {
+				return nil, fmt.Errorf("invalid option %s: %s", ransimUEMetricsKey, err)
+			}
+			return &onosRansimCollector{
+				collector: baseCollector(base),
+				ueMetrics: ueMetrics,
+			}, nil
+		},
+	})
+}
+
+// Collect implements the Collector interface behavior for
+// onosRansimCollector, returning a list of kpis.KPI.
+func (col *onosRansimCollector) Collect() ([]kpis.KPI, error) {
+	kpis := []kpis.KPI{}
+
+	if len(col.config.getAddress()) == 0 {
+		return kpis, fmt.Errorf("onosRansimCollector Collect missing service address")
+	}
+
+	conn, err := col.Connect()
+	if err != nil {
+		return kpis, err
+	}
+	defer conn.Close()
+
+	nodesKPI, err := listRansimNodes(conn)
+	if err != nil {
+		return kpis, err
+	}
+
+	uesKPI, ues, err := listRansimUEs(conn)
+	if err != nil {
+		return kpis, err
+	}
+
+	cellsKPI, err := listRansimCells(conn, ues)
+	if err != nil {
+		return kpis, err
+	}
+
+	kpis = append(kpis, nodesKPI)
+	kpis = append(kpis, cellsKPI)
+
+	if col.ueMetrics {
+		routesKPI, err := listRansimRoutes(conn)
+		if err != nil {
+			return kpis, err
+		}
+		kpis = append(kpis, uesKPI)
+		kpis = append(kpis, routesKPI)
+	}
+
+	return kpis, nil
+}
+
+// ransimID formats the ID of a ransim node or cell.
+func ransimID(id uint64) string {
+	return fmt.Sprintf("%x", id)
+}
+
+// listRansimNodes receives a connection to a ransim service to retrieve
+// its simulated nodes and store them according to the data structure
+// of the kpis.OnosRansimNodes KPI.
+func listRansimNodes(conn *grpc.ClientConn) (kpis.KPI, error) {
+	nodesKPI := kpis.OnosRansimNodes()
+	nodesKPI.Nodes = make(map[string]kpis.RansimNode)
+
+	client := modelapi.NewNodeModelClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), onosRansimTimeout)
+	defer cancel()
+	stream, err := client.ListNodes(ctx, &modelapi.ListNodesRequest{})
+	if err != nil {
+		return nodesKPI, err
+	}
+
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nodesKPI, err
+		}
+
+		node := response.GetNode()
+		if node == nil {
+			continue
+		}
+		nodeID := ransimID(uint64(node.GnbID))
+		nodesKPI.Nodes[nodeID] = kpis.RansimNode{
+			NodeID: nodeID,
+			Status: node.Status,
+			Cells:  float64(len(node.CellNCGIs)),
+		}
+	}
+
+	return nodesKPI, nil
+}
+
+// listRansimCells receives a connection to a ransim service to retrieve
+// its simulated cells and store them according to the data structure
+// of the kpis.OnosRansimCells KPI, counting the UEs served by each cell.
+func listRansimCells(conn *grpc.ClientConn, ues map[string]kpis.RansimUE) (kpis.KPI, error) {
+	cellsKPI := kpis.OnosRansimCells()
+	cellsKPI.Cells = make(map[string]kpis.RansimCell)
+
+	servedUEs := make(map[string]float64)
+	for _, ue := range ues {
+		servedUEs[ue.Serving.CellID]++
+	}
+
+	client := modelapi.NewCellModelClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), onosRansimTimeout)
+	defer cancel()
+	stream, err := client.ListCells(ctx, &modelapi.ListCellsRequest{})
+	if err != nil {
+		return cellsKPI, err
+	}
+
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return cellsKPI, err
+		}
+
+		cell := response.GetCell()
+		if cell == nil {
+			continue
+		}
+		cellID := ransimID(uint64(cell.NCGI))
+		cellsKPI.Cells[cellID] = kpis.RansimCell{
+			CellID:            cellID,
+			CellType:          cell.CellType.String(),
+			Pci:               float64(cell.Pci),
+			Earfcn:            float64(cell.Earfcn),
+			MaxUEs:            float64(cell.MaxUEs),
+			UEs:               servedUEs[cellID],
+			RrcConnectedCount: float64(cell.RrcConnectedCount),
+			RrcIdleCount:      float64(cell.RrcIdleCount),
+		}
+	}
+
+	return cellsKPI, nil
+}
+
+// listRansimUEs receives a connection to a ransim service to retrieve
+// its simulated UEs and store them according to the data structure
+// of the kpis.OnosRansimUEs KPI. It returns the UEs too.
+func listRansimUEs(conn *grpc.ClientConn) (kpis.KPI, map[string]kpis.RansimUE, error) {
+	uesKPI := kpis.OnosRansimUEs()
+	uesKPI.UEs = make(map[string]kpis.RansimUE)
+
+	client := modelapi.NewUEModelClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), onosRansimTimeout)
+	defer cancel()
+	stream, err := client.ListUEs(ctx, &modelapi.ListUEsRequest{})
+	if err != nil {
+		return uesKPI, uesKPI.UEs, err
+	}
+
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return uesKPI, uesKPI.UEs, err
+		}
+
+		ue := response.GetUe()
+		if ue == nil {
+			continue
+		}
+		imsi := strconv.FormatUint(uint64(ue.IMSI), 10)
+		uesKPI.UEs[imsi] = parseRansimUE(imsi, ue)
+	}
+
+	return uesKPI, uesKPI.UEs, nil
+}
+
+func parseRansimUE(imsi string, ue *ransimtypes.Ue) kpis.RansimUE {
+	ransimUE := kpis.RansimUE{
+		IMSI: imsi,
+	}
+
+	if ue.ServingTower != 0 {
+		ransimUE.Serving = kpis.RansimTower{
+			CellID:   ransimID(uint64(ue.ServingTower)),
+			Strength: ue.ServingTowerStrength,
+		}
+	}
+
+	towers := []struct {
+		ncgi     ransimtypes.NCGI
+		strength float64
+	}{
+		{ue.Tower1, ue.Tower1Strength},
+		{ue.Tower2, ue.Tower2Strength},
+		{ue.Tower3, ue.Tower3Strength},
+	}
+	for _, tower := range towers {
+		if tower.ncgi == 0 || tower.ncgi == ue.ServingTower {
+			continue
+		}
+		ransimUE.Neighbors = append(ransimUE.Neighbors, kpis.RansimTower{
+			CellID:   ransimID(uint64(tower.ncgi)),
+			Strength: tower.strength,
+		})
+	}
+
+	if ue.Position != nil {
+		ransimUE.Latitude = ue.Position.Lat
+		ransimUE.Longitude = ue.Position.Lng
+	}
+
+	return ransimUE
+}
+
+// listRansimRoutes receives a connection to a ransim service to retrieve
+// the routes of its simulated UEs and store them according to the data
+// structure of the kpis.OnosRansimRoutes KPI.
+func listRansimRoutes(conn *grpc.ClientConn) (kpis.KPI, error) {
+	routesKPI := kpis.OnosRansimRoutes()
+	routesKPI.Routes = make(map[string]kpis.RansimRoute)
+
+	client := modelapi.NewRouteModelClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), onosRansimTimeout)
+	defer cancel()
+	stream, err := client.ListRoutes(ctx, &modelapi.ListRoutesRequest{})
+	if err != nil {
+		return routesKPI, err
+	}
+
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return routesKPI, err
+		}
+
+		route := response.GetRoute()
+		if route == nil {
+			continue
+		}
+		imsi := strconv.FormatUint(uint64(route.RouteID), 10)
+		routesKPI.Routes[imsi] = kpis.RansimRoute{
+			IMSI:      imsi,
+			Waypoints: float64(len(route.Waypoints)),
+			NextPoint: float64(route.NextPoint),
+		}
+	}
+
+	return routesKPI, nil
+}
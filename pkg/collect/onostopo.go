@@ -8,16 +8,40 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/gogo/protobuf/proto"
 	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	exporterConfig "github.com/onosproject/onos-exporter/pkg/config"
 	"github.com/onosproject/onos-exporter/pkg/kpis"
 	"google.golang.org/grpc"
 )
 
 // onosTopoCollector is the onos topo collector.
 // It extracts all the topo related kpis using the Collect method.
+// The filters restrict the objects and aspects it exports.
 type onosTopoCollector struct {
 	collector
+	filters *topoFilters
+}
+
+func init() {
+	MustRegister(CollectorType{
+		Name:        exporterConfig.ONOSTOPO,
+		Description: "The onos topo entities and relations",
+		Options:     topoOptions,
+		Factory: func(base Base) (Collector, error) {
+			filters, err := newTopoFilters(base)
+			if err != nil {
+				return nil, err
+			}
+			return &onosTopoCollector{
+				collector: baseCollector(base),
+				filters:   filters,
+			}, nil
+		},
+	})
 }
 
 // Collect implements the Collector interface behavior for
@@ -29,59 +53,57 @@ func (col *onosTopoCollector) Collect() ([]kpis.KPI, error) {
 		return kpis, fmt.Errorf("onosTopoCollector Collect missing service address")
 	}
 
-	conn, err := GetConnection(
-		col.config.getAddress(),
-		col.config.getCertPath(),
-		col.config.getKeyPath(),
-		col.config.noTLS(),
-	)
+	conn, err := col.Connect()
 	if err != nil {
 		return kpis, err
 	}
 	defer conn.Close()
 
-	entitiesKPI, err := listEntities(conn)
+	// The summary and the masters of the entities are defined by the whole
+	// topology, listed apart if the filters restrict the exported objects.
+	objects, err := listObjects(conn, col.filters.topoFilters())
 	if err != nil {
 		return kpis, err
 	}
 
-	relationsKPI, err := listRelations(conn)
-	if err != nil {
-		return kpis, err
+	all := objects
+	if !col.filters.isEmpty() {
+		all, err = listObjects(conn, &topoapi.Filters{})
+		if err != nil {
+			return kpis, err
+		}
 	}
 
-	kpis = append(kpis, entitiesKPI)
-	kpis = append(kpis, relationsKPI)
+	kpis = append(kpis, parseEntities(objects, col.filters))
+	kpis = append(kpis, parseRelations(objects, col.filters))
+	kpis = append(kpis, parseAspects(objects, all, col.filters))
+	kpis = append(kpis, parseSummary(all))
 
 	return kpis, err
 }
 
-// listEntities receives a connection to a onos topo service
-// to retrieve the topo Entities and store them according to the
-// data structure of the kpis.OnosTopoEntities KPI.
-func listEntities(conn *grpc.ClientConn) (kpis.KPI, error) {
+// parseEntities receives the topo Objects listed from a onos topo
+// service and store the Entities according to the data structure
+// of the kpis.OnosTopoEntities KPI.
+func parseEntities(objects []topoapi.Object, filters *topoFilters) kpis.KPI {
 	entitiesKPI := kpis.OnosTopoEntities()
 	entitiesKPI.Entities = make(map[string]kpis.TopoEntity)
-
-	filters := &topoapi.Filters{}
-	filters.ObjectTypes = []topoapi.Object_Type{topoapi.Object_ENTITY}
-	objects, err := listObjects(conn, filters)
-
-	if err != nil {
-		return entitiesKPI, err
-	}
+	entitiesKPI.ExpandedLabels = filters.labelNames
 
 	for _, object := range objects {
-		entity := parseObjectEntity(object)
+		if object.GetEntity() == nil {
+			continue
+		}
+		entity := parseObjectEntity(object, filters)
 		entitiesKPI.Entities[entity.ID] = entity
 	}
 
-	return entitiesKPI, nil
+	return entitiesKPI
 }
 
-func parseObjectEntity(obj topoapi.Object) kpis.TopoEntity {
-	labels := labelsAsCSV(obj)
-	aspects := aspectsAsCSV(obj, false)
+func parseObjectEntity(obj topoapi.Object, filters *topoFilters) kpis.TopoEntity {
+	labels := labelsAsCSV(obj, filters)
+	aspects := aspectsAsCSV(obj, filters, false)
 
 	var kindID topoapi.ID
 	if e := obj.GetEntity(); e != nil {
@@ -89,51 +111,258 @@ func parseObjectEntity(obj topoapi.Object) kpis.TopoEntity {
 	}
 
 	return kpis.TopoEntity{
-		ID:      string(obj.ID),
-		Kind:    string(kindID),
-		Labels:  labels,
-		Aspects: aspects,
+		ID:             string(obj.ID),
+		Kind:           string(kindID),
+		Labels:         labels,
+		Aspects:        aspects,
+		ExpandedLabels: expandedLabels(obj, filters),
 	}
 }
 
-// listRelations receives a connection to a onos topo service
-// to retrieve the topo Relations and store them according to the
-// data structure of the kpis.OnosTopoRelations KPI.
-func listRelations(conn *grpc.ClientConn) (kpis.KPI, error) {
+// parseRelations receives the topo Objects listed from a onos topo
+// service and store the Relations according to the data structure
+// of the kpis.OnosTopoRelations KPI.
+func parseRelations(objects []topoapi.Object, filters *topoFilters) kpis.KPI {
 	relationsKPI := kpis.OnosTopoRelations()
 	relationsKPI.Relations = make(map[string]kpis.TopoRelation)
-
-	filters := &topoapi.Filters{}
-	filters.ObjectTypes = []topoapi.Object_Type{topoapi.Object_RELATION}
-	objects, err := listObjects(conn, filters)
-
-	if err != nil {
-		return relationsKPI, err
-	}
+	relationsKPI.ExpandedLabels = filters.labelNames
 
 	for _, object := range objects {
-		relation := parseObjectRelation(object)
+		if object.GetRelation() == nil {
+			continue
+		}
+		relation := parseObjectRelation(object, filters)
 		relationsKPI.Relations[relation.ID] = relation
 	}
 
-	return relationsKPI, nil
+	return relationsKPI
 }
 
-func parseObjectRelation(obj topoapi.Object) kpis.TopoRelation {
-	labels := labelsAsCSV(obj)
-	aspects := aspectsAsCSV(obj, false)
+func parseObjectRelation(obj topoapi.Object, filters *topoFilters) kpis.TopoRelation {
+	labels := labelsAsCSV(obj, filters)
+	aspects := aspectsAsCSV(obj, filters, false)
 	r := obj.GetRelation()
 
 	return kpis.TopoRelation{
-		ID:      string(obj.ID),
-		Kind:    string(r.KindID),
-		Labels:  labels,
-		Source:  string(r.SrcEntityID),
-		Target:  string(r.TgtEntityID),
-		Aspects: aspects,
+		ID:             string(obj.ID),
+		Kind:           string(r.KindID),
+		Labels:         labels,
+		Source:         string(r.SrcEntityID),
+		Target:         string(r.TgtEntityID),
+		Aspects:        aspects,
+		ExpandedLabels: expandedLabels(obj, filters),
 	}
 }
 
+// indexTopology indexes the entities and relations of the topo Objects by ID.
+func indexTopology(objects []topoapi.Object) (map[topoapi.ID]*topoapi.Entity, map[topoapi.ID]*topoapi.Relation) {
+	entities := make(map[topoapi.ID]*topoapi.Entity)
+	relations := make(map[topoapi.ID]*topoapi.Relation)
+	for _, object := range objects {
+		if e := object.GetEntity(); e != nil {
+			entities[object.ID] = e
+		} else if r := object.GetRelation(); r != nil {
+			relations[object.ID] = r
+		}
+	}
+	return entities, relations
+}
+
+// parseAspects receives the topo Objects listed from a onos topo service
+// and decodes the aspects of the Entities according to the data structure
+// of the kpis.OnosTopoAspects KPI, resolving their masters from all the
+// topo Objects.
+func parseAspects(objects, all []topoapi.Object, filters *topoFilters) kpis.KPI {
+	aspectsKPI := kpis.OnosTopoAspects()
+	aspectsKPI.Entities = make(map[string]kpis.TopoEntityAspects)
+
+	_, relations := indexTopology(all)
+
+	for _, object := range objects {
+		if object.GetEntity() == nil {
+			continue
+		}
+		aspects := parseEntityAspects(object, relations, filters)
+		aspectsKPI.Entities[aspects.ID] = aspects
+	}
+
+	return aspectsKPI
+}
+
+// parseSummary receives all the topo Objects listed from a onos topo
+// service and summarizes them according to the data structure of the
+// kpis.OnosTopoSummary KPI.
+func parseSummary(all []topoapi.Object) kpis.KPI {
+	summaryKPI := kpis.OnosTopoSummary()
+	summaryKPI.Summary = summarizeTopology(indexTopology(all))
+	summaryKPI.Summary.CellIDs = indexCellIDs(all)
+	return summaryKPI
+}
+
+// indexCellIDs maps the entity ID, cell object ID and cell global ID
+// of the e2 cells of the topo Objects to their entity IDs.
+func indexCellIDs(all []topoapi.Object) map[string]string {
+	cellIDs := make(map[string]string)
+	for _, object := range all {
+		if e := object.GetEntity(); e == nil || e.KindID != topoapi.E2CELL {
+			continue
+		}
+		cellIDs[string(object.ID)] = string(object.ID)
+
+		e2Cell := &topoapi.E2Cell{}
+		if object.GetAspect(e2Cell) == nil {
+			continue
+		}
+		if e2Cell.CellObjectID != "" {
+			cellIDs[e2Cell.CellObjectID] = string(object.ID)
+		}
+		if e2Cell.CellGlobalID != nil && e2Cell.CellGlobalID.Value != "" {
+			cellIDs[e2Cell.CellGlobalID.Value] = string(object.ID)
+		}
+	}
+	return cellIDs
+}
+
+// summarizeTopology counts the entities and relations per kind, the relations
+// whose source or target entity no longer exists, the cells contained by each
+// e2 node and the e2 nodes controlled by each e2t instance. Nodes and e2t
+// instances without any cell or node count 0.
+func summarizeTopology(entities map[topoapi.ID]*topoapi.Entity, relations map[topoapi.ID]*topoapi.Relation) kpis.TopoSummary {
+	summary := kpis.TopoSummary{
+		Entities:          make(map[string]float64),
+		Relations:         make(map[string]float64),
+		OrphanedRelations: make(map[string]float64),
+		NodeCells:         make(map[string]float64),
+		E2tNodes:          make(map[string]float64),
+	}
+
+	for id, e := range entities {
+		summary.Entities[string(e.KindID)]++
+		switch e.KindID {
+		case topoapi.E2NODE:
+			summary.NodeCells[string(id)] += 0
+		case topoapi.E2T:
+			summary.E2tNodes[string(id)] += 0
+		}
+	}
+
+	for _, r := range relations {
+		summary.Relations[string(r.KindID)]++
+
+		src, srcOk := entities[r.SrcEntityID]
+		tgt, tgtOk := entities[r.TgtEntityID]
+		if !srcOk || !tgtOk {
+			summary.OrphanedRelations[string(r.KindID)]++
+			continue
+		}
+
+		switch {
+		case r.KindID == topoapi.CONTAINS && src.KindID == topoapi.E2NODE && tgt.KindID == topoapi.E2CELL:
+			summary.NodeCells[string(r.SrcEntityID)]++
+		case r.KindID == topoapi.CONTROLS && src.KindID == topoapi.E2T && tgt.KindID == topoapi.E2NODE:
+			summary.E2tNodes[string(r.SrcEntityID)]++
+		}
+	}
+
+	return summary
+}
+
+// parseEntityAspects decodes the E2Node, E2Cell, Location, Coverage,
+// MastershipState and Protocols aspects of an entity allowed by the
+// filters. The mastership state refers to the relation controlling the
+// entity, so its master is the source of that relation (e.g., the e2t
+// instance of an e2 node) if it is known. The protocols state the
+// connection of the targets of onos config (e.g., via gNMI).
+func parseEntityAspects(obj topoapi.Object, relations map[topoapi.ID]*topoapi.Relation, filters *topoFilters) kpis.TopoEntityAspects {
+	aspects := kpis.TopoEntityAspects{
+		ID:   string(obj.ID),
+		Kind: string(obj.GetEntity().KindID),
+	}
+
+	e2Node := &topoapi.E2Node{}
+	if allowAspect(filters, e2Node) && obj.GetAspect(e2Node) != nil {
+		for _, sm := range e2Node.ServiceModels {
+			if sm == nil {
+				continue
+			}
+			aspects.ServiceModels = append(aspects.ServiceModels, kpis.TopoServiceModel{
+				Name: sm.Name,
+				OID:  sm.OID,
+			})
+		}
+	}
+
+	e2Cell := &topoapi.E2Cell{}
+	if allowAspect(filters, e2Cell) && obj.GetAspect(e2Cell) != nil {
+		cell := &kpis.TopoE2Cell{
+			CellObjectID: e2Cell.CellObjectID,
+			CellType:     e2Cell.CellType,
+			AntennaCount: e2Cell.AntennaCount,
+			EARFCN:       e2Cell.EARFCN,
+			PCI:          e2Cell.PCI,
+		}
+		if e2Cell.CellGlobalID != nil {
+			cell.CellGlobalID = e2Cell.CellGlobalID.Value
+			cell.CellGlobalIDType = e2Cell.CellGlobalID.Type.String()
+		}
+		aspects.E2Cell = cell
+	}
+
+	location := &topoapi.Location{}
+	if allowAspect(filters, location) && obj.GetAspect(location) != nil {
+		aspects.Location = &kpis.TopoLocation{
+			Lat: location.Lat,
+			Lng: location.Lng,
+		}
+	}
+
+	coverage := &topoapi.Coverage{}
+	if allowAspect(filters, coverage) && obj.GetAspect(coverage) != nil {
+		aspects.Coverage = &kpis.TopoCoverage{
+			Height:   coverage.Height,
+			ArcWidth: coverage.ArcWidth,
+			Azimuth:  coverage.Azimuth,
+			Tilt:     coverage.Tilt,
+		}
+	}
+
+	mastership := &topoapi.MastershipState{}
+	if allowAspect(filters, mastership) && obj.GetAspect(mastership) != nil {
+		master := mastership.NodeId
+		if r, ok := relations[topoapi.ID(mastership.NodeId)]; ok {
+			master = string(r.SrcEntityID)
+		}
+		aspects.Mastership = &kpis.TopoMastership{
+			Term:   mastership.Term,
+			Master: master,
+		}
+	}
+
+	protocols := &topoapi.Protocols{}
+	if allowAspect(filters, protocols) && obj.GetAspect(protocols) != nil {
+		for _, state := range protocols.State {
+			if state == nil {
+				continue
+			}
+			aspects.Protocols = append(aspects.Protocols, kpis.TopoProtocolState{
+				Protocol:          strings.ToLower(state.Protocol.String()),
+				ConnectivityState: strings.ToLower(state.ConnectivityState.String()),
+				ChannelState:      strings.ToLower(state.ChannelState.String()),
+				ServiceState:      strings.ToLower(state.ServiceState.String()),
+				Connected:         state.ChannelState == topoapi.ChannelState_CONNECTED,
+			})
+		}
+	}
+
+	return aspects
+}
+
+// allowAspect returns whether the filters allow the aspect of
+// the type of the message.
+func allowAspect(filters *topoFilters, aspect proto.Message) bool {
+	return filters.allowAspect(proto.MessageName(aspect))
+}
+
 func listObjects(conn *grpc.ClientConn, filters *topoapi.Filters) ([]topoapi.Object, error) {
 	client := topoapi.CreateTopoClient(conn)
 
@@ -144,26 +373,56 @@ func listObjects(conn *grpc.ClientConn, filters *topoapi.Filters) ([]topoapi.Obj
 	return resp.Objects, nil
 }
 
-func labelsAsCSV(object topoapi.Object) string {
+// labelsAsCSV returns the labels of an object not expanded by the
+// filters as a CSV sorted by key, so it is stable across collections.
+func labelsAsCSV(object topoapi.Object, filters *topoFilters) string {
+	keys := make([]string, 0, len(object.Labels))
+	for k := range object.Labels {
+		if !filters.expandsLabel(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
 	var buffer bytes.Buffer
 	first := true
-	for k, v := range object.Labels {
+	for _, k := range keys {
 		if !first {
 			buffer.WriteString(",")
 		}
 		buffer.WriteString(k)
 		buffer.WriteString("=")
-		buffer.WriteString(v)
+		buffer.WriteString(object.Labels[k])
 		first = false
 	}
 	return buffer.String()
 }
 
-func aspectsAsCSV(object topoapi.Object, verbose bool) string {
+// expandedLabels returns the values of the labels of an object
+// expanded by the filters, empty for the labels it does not have.
+func expandedLabels(object topoapi.Object, filters *topoFilters) []string {
+	values := make([]string, 0, len(filters.labelKeys))
+	for _, k := range filters.labelKeys {
+		values = append(values, object.Labels[k])
+	}
+	return values
+}
+
+func aspectsAsCSV(object topoapi.Object, filters *topoFilters, verbose bool) string {
 	var buffer bytes.Buffer
 	first := true
 	if object.Aspects != nil {
-		for aspectType, aspect := range object.Aspects {
+		aspectTypes := make([]string, 0, len(object.Aspects))
+		for aspectType := range object.Aspects {
+			aspectTypes = append(aspectTypes, aspectType)
+		}
+		sort.Strings(aspectTypes)
+
+		for _, aspectType := range aspectTypes {
+			aspect := object.Aspects[aspectType]
+			if !filters.allowAspect(aspectType) {
+				continue
+			}
 
 			if !first {
 				buffer.WriteString(",")
@@ -12,14 +12,44 @@ import (
 	"time"
 
 	"github.com/onosproject/onos-api/go/onos/uenib"
+	exporterConfig "github.com/onosproject/onos-exporter/pkg/config"
 	"github.com/onosproject/onos-exporter/pkg/kpis"
 	"google.golang.org/grpc"
 )
 
 // onosUenibCollector is the onos uenib collector.
 // It extracts all the uenib related kpis using the Collect method.
+// The servingCellAspect is the aspect type of the UEs whose value
+// is the ID of their serving cell, if any.
 type onosUenibCollector struct {
 	collector
+	servingCellAspect string
+}
+
+// uenibServingCellAspectKey defines the option of the aspect type
+// of the UEs identifying their serving cell.
+const uenibServingCellAspectKey = "uenib.serving-cell-aspect"
+
+// uenibAspectTypes are the aspect types of the UEs exported as labels.
+var uenibAspectTypes = []string{"neighbors", "RRC.Conn.Avg"}
+
+func init() {
+	MustRegister(CollectorType{
+		Name:        exporterConfig.ONOSUENIB,
+		Description: "The onos uenib UEs aspects",
+		Options: []OptionSchema{
+			{
+				Key:         uenibServingCellAspectKey,
+				Description: "aspect type of the UEs whose value is the ID of their serving cell, checked against the onos topo cells",
+			},
+		},
+		Factory: func(base Base) (Collector, error) {
+			return &onosUenibCollector{
+				collector:         baseCollector(base),
+				servingCellAspect: strings.TrimSpace(base.Option(uenibServingCellAspectKey)),
+			}, nil
+		},
+	})
 }
 
 // Collect implements the Collector interface behavior for
@@ -31,18 +61,13 @@ func (col *onosUenibCollector) Collect() ([]kpis.KPI, error) {
 		return kpis, fmt.Errorf("onosUenibCollector Collect missing service address")
 	}
 
-	conn, err := GetConnection(
-		col.config.getAddress(),
-		col.config.getCertPath(),
-		col.config.getKeyPath(),
-		col.config.noTLS(),
-	)
+	conn, err := col.Connect()
 	if err != nil {
 		return kpis, err
 	}
 	defer conn.Close()
 
-	uenibKPI, err := listUEs(conn)
+	uenibKPI, err := listUEs(conn, col.servingCellAspect)
 	if err != nil {
 		return kpis, err
 	}
@@ -54,12 +79,22 @@ func (col *onosUenibCollector) Collect() ([]kpis.KPI, error) {
 
 // listUEs receives a connection to a onos uenib service
 // to retrieve the uenib UEs Aspects and store them according to the
-// data structure of the kpis.OnosUenibUEs KPI.
-func listUEs(conn *grpc.ClientConn) (kpis.KPI, error) {
+// data structure of the kpis.OnosUenibUEs KPI. The serving cell of
+// the UEs is the value of their servingCellAspect, if not empty, which
+// is not exported as an aspect unless it is one of uenibAspectTypes.
+func listUEs(conn *grpc.ClientConn, servingCellAspect string) (kpis.KPI, error) {
 	uenibKPI := kpis.OnosUenibUEs()
 	uenibKPI.UEs = make(map[string]kpis.UE)
 
-	aspectTypes := []string{"neighbors", "RRC.Conn.Avg"}
+	aspectTypes := append([]string{}, uenibAspectTypes...)
+	ignoredAspect := ""
+	if servingCellAspect != "" {
+		uenibKPI.ServingCells = true
+		if !containsString(aspectTypes, servingCellAspect) {
+			aspectTypes = append(aspectTypes, servingCellAspect)
+			ignoredAspect = servingCellAspect
+		}
+	}
 
 	client := uenib.CreateUEServiceClient(conn)
 
@@ -82,7 +117,10 @@ func listUEs(conn *grpc.ClientConn) (kpis.KPI, error) {
 		} else if err != nil {
 			return uenibKPI, err
 		} else {
-			ue := parseObjectUE(resp.UE)
+			ue := parseObjectUE(resp.UE, ignoredAspect)
+			if any, ok := resp.UE.Aspects[servingCellAspect]; ok && servingCellAspect != "" {
+				ue.ServingCell = strings.Trim(strings.TrimSpace(string(any.Value)), `"`)
+			}
 			uenibKPI.UEs[ue.ID] = ue
 
 		}
@@ -91,11 +129,16 @@ func listUEs(conn *grpc.ClientConn) (kpis.KPI, error) {
 	return uenibKPI, nil
 }
 
-func parseObjectUE(ue uenib.UE) kpis.UE {
+// parseObjectUE returns the kpis.UE of a uenib UE, with all its
+// aspects except the ignoredAspect.
+func parseObjectUE(ue uenib.UE, ignoredAspect string) kpis.UE {
 	aspects := []string{}
 	aspectsValues := []string{}
 
 	for aspectType, any := range ue.Aspects {
+		if aspectType == ignoredAspect {
+			continue
+		}
 		aspectType = strings.ToLower(strings.ReplaceAll(aspectType, ".", "_"))
 		aspects = append(aspects, aspectType)
 		aspectsValues = append(aspectsValues, string(any.Value))
@@ -107,3 +150,13 @@ func parseObjectUE(ue uenib.UE) kpis.UE {
 		AspectsValues: aspectsValues,
 	}
 }
+
+// containsString checks if values contain value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-exporter/pkg/kpis"
+)
+
+// pciHistory keeps the PCI changes and the resolved conflicts of the
+// pci xapp cells across collections. The first PCI seen for a cell is
+// its baseline, so it does not count as a change. The resolved conflicts
+// reported by the xapp are accumulated, a decrease of them (e.g., after
+// an xapp restart) counting the new value, so the counters only increase.
+// Cells not listed by the xapp within window are pruned.
+type pciHistory struct {
+	window time.Duration
+
+	mu    sync.Mutex
+	cells map[string]*pciCellHistory
+}
+
+type pciCellHistory struct {
+	pci               uint32
+	previousPci       uint32
+	changes           float64
+	lastChange        time.Time
+	resolved          uint32
+	resolvedConflicts float64
+	seenPci           bool
+	seenResolved      bool
+	lastSeen          time.Time
+}
+
+func newPciHistory(window time.Duration) *pciHistory {
+	return &pciHistory{
+		window: window,
+		cells:  make(map[string]*pciCellHistory),
+	}
+}
+
+// cell returns the history of the cell cellID, seen at now.
+func (h *pciHistory) cell(cellID string, now time.Time) *pciCellHistory {
+	cell, ok := h.cells[cellID]
+	if !ok {
+		cell = &pciCellHistory{}
+		h.cells[cellID] = cell
+	}
+	cell.lastSeen = now
+	return cell
+}
+
+// prune removes the cells not seen within the window before now.
+func (h *pciHistory) prune(now time.Time) {
+	for cellID, cell := range h.cells {
+		if now.Sub(cell.lastSeen) > h.window {
+			delete(h.cells, cellID)
+		}
+	}
+}
+
+// updatePcis records the current PCI of the cells at now.
+func (h *pciHistory) updatePcis(cells map[string]kpis.CellInfo, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for cellID, cellInfo := range cells {
+		cell := h.cell(cellID, now)
+		if cell.seenPci && cell.pci != cellInfo.Pci {
+			cell.previousPci = cell.pci
+			cell.changes++
+			cell.lastChange = now
+		}
+		cell.pci = cellInfo.Pci
+		cell.seenPci = true
+	}
+
+	h.prune(now)
+}
+
+// updateResolvedConflicts records the resolved conflicts of the cells at now.
+func (h *pciHistory) updateResolvedConflicts(cells map[string]kpis.CellConflict, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for cellID, cellConflict := range cells {
+		cell := h.cell(cellID, now)
+		resolved := uint32(cellConflict.ResolvedConflicts)
+		switch {
+		case !cell.seenResolved:
+			cell.resolvedConflicts = float64(resolved)
+		case resolved >= cell.resolved:
+			cell.resolvedConflicts += float64(resolved - cell.resolved)
+		default:
+			cell.resolvedConflicts += float64(resolved)
+		}
+		cell.resolved = resolved
+		cell.seenResolved = true
+	}
+
+	h.prune(now)
+}
+
+// KPI returns the kpi XappPciHistory of the cells.
+func (h *pciHistory) KPI() kpis.KPI {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	historyKPI := kpis.XappPciHistory()
+	historyKPI.Cells = make(map[string]kpis.CellHistory)
+
+	for cellID, cell := range h.cells {
+		cellHistory := kpis.CellHistory{
+			CellID:            cellID,
+			PciChanges:        cell.changes,
+			ResolvedConflicts: cell.resolvedConflicts,
+		}
+		if !cell.lastChange.IsZero() {
+			cellHistory.PreviousPci = float64(cell.previousPci)
+			cellHistory.LastChange = float64(cell.lastChange.UnixNano()) / float64(time.Second)
+		}
+		historyKPI.Cells[cellID] = cellHistory
+	}
+
+	return historyKPI
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-exporter/pkg/kpis"
+	"github.com/stretchr/testify/assert"
+)
+
+// pciUpdate is an update of the PCIs and resolved conflicts of the
+// cells made at a time since the start of a test.
+type pciUpdate struct {
+	at       time.Duration
+	pcis     map[string]uint32
+	resolved map[string]float64
+}
+
+func TestPciHistory(t *testing.T) {
+	start := time.Unix(1000, 0)
+
+	tests := []struct {
+		name     string
+		updates  []pciUpdate
+		expected map[string]kpis.CellHistory
+	}{
+		{
+			name: "baseline",
+			updates: []pciUpdate{
+				{at: 0, pcis: map[string]uint32{"a": 1}},
+			},
+			expected: map[string]kpis.CellHistory{
+				"a": {CellID: "a"},
+			},
+		},
+		{
+			name: "pci changes",
+			updates: []pciUpdate{
+				{at: 0, pcis: map[string]uint32{"a": 1, "b": 3}},
+				{at: time.Minute, pcis: map[string]uint32{"a": 2, "b": 3}},
+				{at: 2 * time.Minute, pcis: map[string]uint32{"a": 2, "b": 3}},
+			},
+			expected: map[string]kpis.CellHistory{
+				"a": {CellID: "a", PciChanges: 1, PreviousPci: 1, LastChange: 1060},
+				"b": {CellID: "b"},
+			},
+		},
+		{
+			name: "resolved conflicts",
+			updates: []pciUpdate{
+				{at: 0, resolved: map[string]float64{"a": 3}},
+				{at: time.Minute, resolved: map[string]float64{"a": 5}},
+			},
+			expected: map[string]kpis.CellHistory{
+				"a": {CellID: "a", ResolvedConflicts: 5},
+			},
+		},
+		{
+			name: "resolved conflicts decrease",
+			updates: []pciUpdate{
+				{at: 0, resolved: map[string]float64{"a": 5}},
+				{at: time.Minute, resolved: map[string]float64{"a": 2}},
+				{at: 2 * time.Minute, resolved: map[string]float64{"a": 3}},
+			},
+			expected: map[string]kpis.CellHistory{
+				"a": {CellID: "a", ResolvedConflicts: 8},
+			},
+		},
+		{
+			name: "cells within window",
+			updates: []pciUpdate{
+				{at: 0, pcis: map[string]uint32{"a": 1, "b": 2}},
+				{at: 30 * time.Minute, pcis: map[string]uint32{"b": 2}},
+			},
+			expected: map[string]kpis.CellHistory{
+				"a": {CellID: "a"},
+				"b": {CellID: "b"},
+			},
+		},
+		{
+			name: "pruning",
+			updates: []pciUpdate{
+				{at: 0, pcis: map[string]uint32{"a": 1, "b": 2}},
+				{at: 2 * time.Hour, pcis: map[string]uint32{"b": 2}},
+			},
+			expected: map[string]kpis.CellHistory{
+				"b": {CellID: "b"},
+			},
+		},
+		{
+			name: "pruning of resolved conflicts",
+			updates: []pciUpdate{
+				{at: 0, resolved: map[string]float64{"a": 1, "b": 2}},
+				{at: 2 * time.Hour, resolved: map[string]float64{"b": 2}},
+			},
+			expected: map[string]kpis.CellHistory{
+				"b": {CellID: "b", ResolvedConflicts: 2},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			history := newPciHistory(time.Hour)
+			for _, update := range test.updates {
+				now := start.Add(update.at)
+				if update.pcis != nil {
+					cells := make(map[string]kpis.CellInfo)
+					for cellID, pci := range update.pcis {
+						cells[cellID] = kpis.CellInfo{CellID: cellID, Pci: pci}
+					}
+					history.updatePcis(cells, now)
+				}
+				if update.resolved != nil {
+					cells := make(map[string]kpis.CellConflict)
+					for cellID, resolved := range update.resolved {
+						cells[cellID] = kpis.CellConflict{CellID: cellID, ResolvedConflicts: resolved}
+					}
+					history.updateResolvedConflicts(cells, now)
+				}
+			}
+
+			expected := kpis.XappPciHistory()
+			expected.Cells = test.expected
+			assert.Equal(t, expected, history.KPI())
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc"
+)
+
+// Base defines the common behavior of collectors provided to
+// the Factory of a collector type: the name of the collector type,
+// the values of its configuration options and the connection to
+// its service.
+type Base interface {
+	Name() string
+	Option(key string) string
+	Connect() (*grpc.ClientConn, error)
+}
+
+// Factory defines the function that creates a Collector of
+// a registered collector type from its Base.
+type Factory func(base Base) (Collector, error)
+
+// OptionSchema describes a configuration option specific to
+// a collector type, in addition to the common ones defined
+// in config.go (e.g., AddressKey).
+type OptionSchema struct {
+	Key         string
+	Description string
+	Default     string
+}
+
+// CollectorType defines a collector type that can be registered,
+// so collectors of its Name can be created by CreateCollector.
+// Options define the schema of its configuration options.
+type CollectorType struct {
+	Name        string
+	Description string
+	Options     []OptionSchema
+	Factory     Factory
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]CollectorType)
+)
+
+// Register adds a collector type to the registry of collectors.
+// Collector types can be registered by other modules, usually in
+// the init function of their package.
+func Register(colType CollectorType) error {
+	if colType.Name == "" {
+		return fmt.Errorf("collector type must have a name")
+	}
+	if colType.Factory == nil {
+		return fmt.Errorf("collector type %s must have a factory", colType.Name)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[colType.Name]; ok {
+		return fmt.Errorf("collector type %s already registered", colType.Name)
+	}
+	registry[colType.Name] = colType
+	return nil
+}
+
+// MustRegister adds a collector type to the registry of collectors,
+// panicking if it can not be registered.
+func MustRegister(colType CollectorType) {
+	if err := Register(colType); err != nil {
+		panic(err)
+	}
+}
+
+// LookupType returns the registered collector type named name.
+func LookupType(name string) (CollectorType, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	colType, ok := registry[name]
+	return colType, ok
+}
+
+// RegisteredTypes returns all the registered collector types
+// sorted by name.
+func RegisteredTypes() []CollectorType {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	colTypes := make([]CollectorType, 0, len(registry))
+	for _, colType := range registry {
+		colTypes = append(colTypes, colType)
+	}
+	sort.Slice(colTypes, func(i, j int) bool {
+		return colTypes[i].Name < colTypes[j].Name
+	})
+	return colTypes
+}
+
+// optionKeys returns the keys of the options of a collector type.
+func (t CollectorType) optionKeys() []string {
+	keys := make([]string, 0, len(t.Options))
+	for _, option := range t.Options {
+		keys = append(keys, option.Key)
+	}
+	return keys
+}
+
+// optionDefaults returns the default values of the options
+// of a collector type.
+func (t CollectorType) optionDefaults() map[string]string {
+	defaults := make(map[string]string)
+	for _, option := range t.Options {
+		if option.Default != "" {
+			defaults[option.Key] = option.Default
+		}
+	}
+	return defaults
+}
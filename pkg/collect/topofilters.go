@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"fmt"
+	"strings"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+)
+
+// Consts define the options of the onos topo collector restricting
+// the objects, aspects and labels it exports.
+const (
+	topoKindsKey          = "topo.kinds"
+	topoLabelsKey         = "topo.labels"
+	topoObjectTypesKey    = "topo.object-types"
+	topoIncludeAspectsKey = "topo.include-aspects"
+	topoExcludeAspectsKey = "topo.exclude-aspects"
+	topoLabelKeysKey      = "topo.label-keys"
+)
+
+// topoLabelPrefix prefixes the Prometheus labels expanded from topo
+// labels, so they do not clash with the labels of the topo metrics.
+const topoLabelPrefix = "label_"
+
+// topoOptions defines the schema of the options of the onos topo collector.
+var topoOptions = []OptionSchema{
+	{
+		Key:         topoKindsKey,
+		Description: "comma separated kind IDs of the exported entities and relations (e.g., e2node,e2cell,controls,contains)",
+	},
+	{
+		Key:         topoLabelsKey,
+		Description: "comma separated label selectors of the exported objects: key=value, key!=value or key=value1|value2",
+	},
+	{
+		Key:         topoObjectTypesKey,
+		Description: "comma separated types of the exported objects: entity, relation",
+		Default:     "entity,relation",
+	},
+	{
+		Key:         topoIncludeAspectsKey,
+		Description: "comma separated aspect types exported, all if empty (e.g., onos.topo.E2Cell or E2Cell)",
+	},
+	{
+		Key:         topoExcludeAspectsKey,
+		Description: "comma separated aspect types not exported (e.g., onos.topo.Location or Location)",
+	},
+	{
+		Key:         topoLabelKeysKey,
+		Description: "comma separated topo label keys exported as individual labels named label_<sanitized key>, the other labels being exported as CSV",
+	},
+}
+
+// topoFilters defines the objects listed by the onos topo collector,
+// translated into topoapi.Filters, the aspects it exports and the topo
+// labels it expands into the Prometheus labels of labelNames.
+type topoFilters struct {
+	kinds          []string
+	labels         []*topoapi.Filter
+	objectTypes    []topoapi.Object_Type
+	includeAspects map[string]bool
+	excludeAspects map[string]bool
+	labelKeys      []string
+	labelNames     []string
+}
+
+func newTopoFilters(base Base) (*topoFilters, error) {
+	filters := &topoFilters{
+		kinds:          splitOption(base.Option(topoKindsKey)),
+		includeAspects: make(map[string]bool),
+		excludeAspects: make(map[string]bool),
+	}
+
+	for _, selector := range splitOption(base.Option(topoLabelsKey)) {
+		filter, err := parseLabelSelector(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid option %s: %s", topoLabelsKey, err)
+		}
+		filters.labels = append(filters.labels, filter)
+	}
+
+	for _, objectType := range splitOption(base.Option(topoObjectTypesKey)) {
+		value, ok := topoapi.Object_Type_value[strings.ToUpper(objectType)]
+		if !ok || value == int32(topoapi.Object_UNSPECIFIED) {
+			return nil, fmt.Errorf("invalid option %s: unknown object type %s", topoObjectTypesKey, objectType)
+		}
+		filters.objectTypes = append(filters.objectTypes, topoapi.Object_Type(value))
+	}
+
+	for _, aspectType := range splitOption(base.Option(topoIncludeAspectsKey)) {
+		filters.includeAspects[aspectType] = true
+	}
+	for _, aspectType := range splitOption(base.Option(topoExcludeAspectsKey)) {
+		filters.excludeAspects[aspectType] = true
+	}
+
+	names := make(map[string]string)
+	for _, key := range splitOption(base.Option(topoLabelKeysKey)) {
+		name := topoLabelPrefix + sanitizeLabelName(key)
+		if other, ok := names[name]; ok {
+			if other == key {
+				continue
+			}
+			return nil, fmt.Errorf("invalid option %s: label keys %s and %s are both exported as %s", topoLabelKeysKey, other, key, name)
+		}
+		names[name] = key
+		filters.labelKeys = append(filters.labelKeys, key)
+		filters.labelNames = append(filters.labelNames, name)
+	}
+
+	return filters, nil
+}
+
+// sanitizeLabelName replaces the characters of a topo label key
+// not allowed in Prometheus label names by underscores.
+func sanitizeLabelName(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// parseLabelSelector parses a label selector key=value, key!=value
+// or key=value1|value2 into a topoapi.Filter.
+func parseLabelSelector(selector string) (*topoapi.Filter, error) {
+	invalid := fmt.Errorf("label selector %s must be key=value, key!=value or key=value1|value2", selector)
+
+	if i := strings.Index(selector, "!="); i >= 0 {
+		key := strings.TrimSpace(selector[:i])
+		if key == "" {
+			return nil, invalid
+		}
+		return &topoapi.Filter{
+			Key: key,
+			Filter: &topoapi.Filter_Not{Not: &topoapi.NotFilter{
+				Inner: &topoapi.Filter{
+					Filter: &topoapi.Filter_Equal_{Equal_: &topoapi.EqualFilter{Value: strings.TrimSpace(selector[i+2:])}},
+				},
+			}},
+		}, nil
+	}
+
+	i := strings.Index(selector, "=")
+	if i < 0 {
+		return nil, invalid
+	}
+	key, value := strings.TrimSpace(selector[:i]), strings.TrimSpace(selector[i+1:])
+	if key == "" {
+		return nil, invalid
+	}
+	if strings.Contains(value, "|") {
+		values := strings.Split(value, "|")
+		for j := range values {
+			values[j] = strings.TrimSpace(values[j])
+		}
+		return &topoapi.Filter{
+			Key:    key,
+			Filter: &topoapi.Filter_In{In: &topoapi.InFilter{Values: values}},
+		}, nil
+	}
+	return &topoapi.Filter{
+		Key:    key,
+		Filter: &topoapi.Filter_Equal_{Equal_: &topoapi.EqualFilter{Value: value}},
+	}, nil
+}
+
+// splitOption splits a comma separated option value,
+// ignoring empty elements.
+func splitOption(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// isEmpty returns whether the filters select all the objects.
+func (f *topoFilters) isEmpty() bool {
+	return len(f.kinds) == 0 && len(f.labels) == 0 && len(f.objectTypes) != 1
+}
+
+// topoFilters returns the topoapi.Filters selecting the objects.
+func (f *topoFilters) topoFilters() *topoapi.Filters {
+	filters := &topoapi.Filters{
+		LabelFilters: f.labels,
+		ObjectTypes:  f.objectTypes,
+	}
+	if len(f.kinds) > 0 {
+		filters.KindFilter = &topoapi.Filter{
+			Filter: &topoapi.Filter_In{In: &topoapi.InFilter{Values: f.kinds}},
+		}
+	}
+	return filters
+}
+
+// expandsLabel returns whether a topo label key is expanded
+// into its own Prometheus label.
+func (f *topoFilters) expandsLabel(key string) bool {
+	for _, k := range f.labelKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// allowAspect returns whether an aspect type is exported, matching
+// the aspect types of the options with their full or short names
+// (e.g., onos.topo.E2Cell or E2Cell).
+func (f *topoFilters) allowAspect(aspectType string) bool {
+	shortType := aspectType[strings.LastIndex(aspectType, ".")+1:]
+	if f.excludeAspects[aspectType] || f.excludeAspects[shortType] {
+		return false
+	}
+	if len(f.includeAspects) == 0 {
+		return true
+	}
+	return f.includeAspects[aspectType] || f.includeAspects[shortType]
+}
@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"testing"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// testBase is a Base defined by its options.
+type testBase struct {
+	options map[string]string
+}
+
+func (b *testBase) Name() string {
+	return "test"
+}
+
+func (b *testBase) Option(key string) string {
+	return b.options[key]
+}
+
+func (b *testBase) Connect() (*grpc.ClientConn, error) {
+	return nil, nil
+}
+
+func equalFilter(key, value string) *topoapi.Filter {
+	return &topoapi.Filter{
+		Key:    key,
+		Filter: &topoapi.Filter_Equal_{Equal_: &topoapi.EqualFilter{Value: value}},
+	}
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		expected *topoapi.Filter
+	}{
+		{
+			selector: "site=a",
+			expected: equalFilter("site", "a"),
+		},
+		{
+			selector: " site = a ",
+			expected: equalFilter("site", "a"),
+		},
+		{
+			selector: "site!=a",
+			expected: &topoapi.Filter{
+				Key: "site",
+				Filter: &topoapi.Filter_Not{Not: &topoapi.NotFilter{
+					Inner: &topoapi.Filter{
+						Filter: &topoapi.Filter_Equal_{Equal_: &topoapi.EqualFilter{Value: "a"}},
+					},
+				}},
+			},
+		},
+		{
+			selector: "site=a|b",
+			expected: &topoapi.Filter{
+				Key:    "site",
+				Filter: &topoapi.Filter_In{In: &topoapi.InFilter{Values: []string{"a", "b"}}},
+			},
+		},
+		{
+			selector: "site=",
+			expected: equalFilter("site", ""),
+		},
+		{
+			selector: "site",
+		},
+		{
+			selector: "=a",
+		},
+		{
+			selector: "!=a",
+		},
+		{
+			selector: " =a",
+		},
+		{
+			selector: " !=a",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.selector, func(t *testing.T) {
+			filter, err := parseLabelSelector(test.selector)
+			if test.expected == nil {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, filter)
+		})
+	}
+}
+
+func TestNewTopoFilters(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    map[string]string
+		expected   *topoapi.Filters
+		labelNames []string
+		err        bool
+	}{
+		{
+			name:     "defaults",
+			options:  map[string]string{topoObjectTypesKey: "entity,relation"},
+			expected: &topoapi.Filters{ObjectTypes: []topoapi.Object_Type{topoapi.Object_ENTITY, topoapi.Object_RELATION}},
+		},
+		{
+			name: "kinds and labels",
+			options: map[string]string{
+				topoKindsKey:       "e2node, e2cell",
+				topoLabelsKey:      "site=a,,tier=edge",
+				topoObjectTypesKey: "Entity",
+			},
+			expected: &topoapi.Filters{
+				KindFilter: &topoapi.Filter{
+					Filter: &topoapi.Filter_In{In: &topoapi.InFilter{Values: []string{"e2node", "e2cell"}}},
+				},
+				LabelFilters: []*topoapi.Filter{equalFilter("site", "a"), equalFilter("tier", "edge")},
+				ObjectTypes:  []topoapi.Object_Type{topoapi.Object_ENTITY},
+			},
+		},
+		{
+			name:    "invalid label selector",
+			options: map[string]string{topoLabelsKey: "site"},
+			err:     true,
+		},
+		{
+			name:    "unknown object type",
+			options: map[string]string{topoObjectTypesKey: "entity,kind,link"},
+			err:     true,
+		},
+		{
+			name:    "unspecified object type",
+			options: map[string]string{topoObjectTypesKey: "unspecified"},
+			err:     true,
+		},
+		{
+			name:       "label keys",
+			options:    map[string]string{topoLabelKeysKey: "site,onos.io/tier,site"},
+			expected:   &topoapi.Filters{},
+			labelNames: []string{"label_site", "label_onos_io_tier"},
+		},
+		{
+			name:    "label key collision",
+			options: map[string]string{topoLabelKeysKey: "onos.io/tier,onos-io_tier"},
+			err:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filters, err := newTopoFilters(&testBase{options: test.options})
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, filters.topoFilters())
+			assert.Equal(t, test.labelNames, filters.labelNames)
+		})
+	}
+}
+
+func TestTopoFiltersIsEmpty(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  map[string]string
+		expected bool
+	}{
+		{
+			name:     "all objects",
+			options:  map[string]string{topoObjectTypesKey: "entity,relation"},
+			expected: true,
+		},
+		{
+			name:     "no object types",
+			options:  map[string]string{},
+			expected: true,
+		},
+		{
+			name:    "one object type",
+			options: map[string]string{topoObjectTypesKey: "entity"},
+		},
+		{
+			name:    "kinds",
+			options: map[string]string{topoKindsKey: "e2node"},
+		},
+		{
+			name:    "labels",
+			options: map[string]string{topoLabelsKey: "site=a"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filters, err := newTopoFilters(&testBase{options: test.options})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, filters.isEmpty())
+		})
+	}
+}
+
+func TestTopoFiltersAllowAspect(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  map[string]string
+		aspect   string
+		expected bool
+	}{
+		{
+			name:     "all aspects",
+			aspect:   "onos.topo.E2Cell",
+			expected: true,
+		},
+		{
+			name:     "included full name",
+			options:  map[string]string{topoIncludeAspectsKey: "onos.topo.E2Cell"},
+			aspect:   "onos.topo.E2Cell",
+			expected: true,
+		},
+		{
+			name:     "included short name",
+			options:  map[string]string{topoIncludeAspectsKey: "E2Cell"},
+			aspect:   "onos.topo.E2Cell",
+			expected: true,
+		},
+		{
+			name:    "not included",
+			options: map[string]string{topoIncludeAspectsKey: "E2Cell"},
+			aspect:  "onos.topo.Location",
+		},
+		{
+			name:    "excluded",
+			options: map[string]string{topoExcludeAspectsKey: "Location"},
+			aspect:  "onos.topo.Location",
+		},
+		{
+			name:    "included and excluded",
+			options: map[string]string{topoIncludeAspectsKey: "Location", topoExcludeAspectsKey: "onos.topo.Location"},
+			aspect:  "onos.topo.Location",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filters, err := newTopoFilters(&testBase{options: test.options})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, filters.allowAspect(test.aspect))
+		})
+	}
+}
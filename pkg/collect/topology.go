@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"fmt"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+)
+
+// TopologyEntity defines an entity of a Topology. Aspects maps
+// the aspect types of the entity to their JSON values.
+type TopologyEntity struct {
+	ID      string
+	Kind    string
+	Labels  map[string]string
+	Aspects map[string]string
+}
+
+// TopologyRelation defines a relation of a Topology between
+// the entities Source and Target.
+type TopologyRelation struct {
+	ID      string
+	Kind    string
+	Source  string
+	Target  string
+	Labels  map[string]string
+	Aspects map[string]string
+}
+
+// Topology defines the entities and relations retrieved
+// by a TopologyCollector.
+type Topology struct {
+	Entities  []TopologyEntity
+	Relations []TopologyRelation
+}
+
+// TopologyCollector defines the behavior of the collectors
+// providing the current topology, besides its kpis.
+type TopologyCollector interface {
+	Topology() (Topology, error)
+}
+
+// AsTopologyCollector returns the TopologyCollector of col,
+// if col or the collector it wraps provides a topology.
+func AsTopologyCollector(col Collector) (TopologyCollector, bool) {
+	for {
+		if topoCol, ok := col.(TopologyCollector); ok {
+			return topoCol, true
+		}
+		labeledCol, ok := col.(*labeledCollector)
+		if !ok {
+			return nil, false
+		}
+		col = labeledCol.Collector
+	}
+}
+
+// Topology implements the TopologyCollector interface behavior for
+// onosTopoCollector, returning the topology objects selected by its
+// filters, with their allowed aspects.
+func (col *onosTopoCollector) Topology() (Topology, error) {
+	topology := Topology{}
+
+	if len(col.config.getAddress()) == 0 {
+		return topology, fmt.Errorf("onosTopoCollector Topology missing service address")
+	}
+
+	conn, err := col.Connect()
+	if err != nil {
+		return topology, err
+	}
+	defer conn.Close()
+
+	objects, err := listObjects(conn, col.filters.topoFilters())
+	if err != nil {
+		return topology, err
+	}
+
+	for _, object := range objects {
+		if e := object.GetEntity(); e != nil {
+			topology.Entities = append(topology.Entities, TopologyEntity{
+				ID:      string(object.ID),
+				Kind:    string(e.KindID),
+				Labels:  object.Labels,
+				Aspects: topologyAspects(object, col.filters),
+			})
+		} else if r := object.GetRelation(); r != nil {
+			topology.Relations = append(topology.Relations, TopologyRelation{
+				ID:      string(object.ID),
+				Kind:    string(r.KindID),
+				Source:  string(r.SrcEntityID),
+				Target:  string(r.TgtEntityID),
+				Labels:  object.Labels,
+				Aspects: topologyAspects(object, col.filters),
+			})
+		}
+	}
+
+	return topology, nil
+}
+
+// topologyAspects returns the JSON values of the aspects
+// of an object allowed by the filters.
+func topologyAspects(object topoapi.Object, filters *topoFilters) map[string]string {
+	aspects := make(map[string]string)
+	for aspectType, aspect := range object.Aspects {
+		if aspect != nil && filters.allowAspect(aspectType) {
+			aspects[aspectType] = string(aspect.Value)
+		}
+	}
+	return aspects
+}
@@ -12,6 +12,7 @@ import (
 	prototypes "github.com/gogo/protobuf/types"
 
 	kpimonapi "github.com/onosproject/onos-api/go/onos/kpimon"
+	exporterConfig "github.com/onosproject/onos-exporter/pkg/config"
 	"github.com/onosproject/onos-exporter/pkg/kpis"
 	"google.golang.org/grpc"
 )
@@ -22,6 +23,18 @@ type xappKpimonCollector struct {
 	collector
 }
 
+func init() {
+	MustRegister(CollectorType{
+		Name:        exporterConfig.ONOSXAPPKPIMON,
+		Description: "The kpimon xapp KPM measurements",
+		Factory: func(base Base) (Collector, error) {
+			return &xappKpimonCollector{
+				collector: baseCollector(base),
+			}, nil
+		},
+	})
+}
+
 // Collect implements the Collector interface behavior for
 // XappKpimonCollector, returning a list of kpis.KPI.
 func (col *xappKpimonCollector) Collect() ([]kpis.KPI, error) {
@@ -31,12 +44,7 @@ func (col *xappKpimonCollector) Collect() ([]kpis.KPI, error) {
 		return kpis, fmt.Errorf("XappKpimonCollector Collect missing service address")
 	}
 
-	conn, err := GetConnection(
-		col.config.getAddress(),
-		col.config.getCertPath(),
-		col.config.getKeyPath(),
-		col.config.noTLS(),
-	)
+	conn, err := col.Connect()
 	if err != nil {
 		return kpis, err
 	}
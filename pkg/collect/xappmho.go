@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"context"
+	"fmt"
+
+	mhoapi "github.com/onosproject/onos-api/go/onos/mho"
+	exporterConfig "github.com/onosproject/onos-exporter/pkg/config"
+	"github.com/onosproject/onos-exporter/pkg/kpis"
+	"google.golang.org/grpc"
+)
+
+// xappMhoCollector is the onos xapp mho collector.
+// It extracts all the mho related kpis using the Collect method.
+// The mho API only provides the handover parameters of the xapp,
+// the per UE and per cell handover state is not available from it.
+type xappMhoCollector struct {
+	collector
+}
+
+func init() {
+	MustRegister(CollectorType{
+		Name:        exporterConfig.ONOSXAPPMHO,
+		Description: "The mho xapp A3 event handover parameters",
+		Factory: func(base Base) (Collector, error) {
+			return &xappMhoCollector{
+				collector: baseCollector(base),
+			}, nil
+		},
+	})
+}
+
+// Collect implements the Collector interface behavior for
+// XappMhoCollector, returning a list of kpis.KPI.
+func (col *xappMhoCollector) Collect() ([]kpis.KPI, error) {
+	kpis := []kpis.KPI{}
+
+	if len(col.config.getAddress()) == 0 {
+		return kpis, fmt.Errorf("XappMhoCollector Collect missing service address")
+	}
+
+	conn, err := col.Connect()
+	if err != nil {
+		return kpis, err
+	}
+	defer conn.Close()
+
+	paramsKPI, err := listMhoParams(conn)
+	if err != nil {
+		return kpis, err
+	}
+
+	kpis = append(kpis, paramsKPI)
+
+	return kpis, err
+}
+
+// listMhoParams receives a connection to a mho xapp service
+// to retrieve all its handover parameters and store them according
+// to the data structure of the kpis.XappMhoParams KPI.
+func listMhoParams(conn *grpc.ClientConn) (kpis.KPI, error) {
+	paramsKPI := kpis.XappMhoParams()
+
+	request := mhoapi.GetMhoParamRequest{
+		HoParamType: mhoapi.MhoParamType_ALL,
+	}
+	client := mhoapi.NewMhoClient(conn)
+	response, err := client.GetMhoParams(context.Background(), &request)
+	if err != nil {
+		return paramsKPI, err
+	}
+
+	paramsKPI.Params = kpis.MhoParams{
+		A3Offset:      float64(response.GetA3Offset()),
+		Hysteresis:    float64(response.GetHysteresis()),
+		TimeToTrigger: float64(response.GetTimeToTrigger()),
+	}
+
+	return paramsKPI, nil
+}
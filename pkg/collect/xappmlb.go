@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package collect
+
+import (
+	"context"
+	"fmt"
+
+	mlbapi "github.com/onosproject/onos-api/go/onos/mlb"
+	exporterConfig "github.com/onosproject/onos-exporter/pkg/config"
+	"github.com/onosproject/onos-exporter/pkg/kpis"
+	"google.golang.org/grpc"
+)
+
+// xappMlbCollector is the onos xapp mlb collector.
+// It extracts all the mlb related kpis using the Collect method.
+// The mlb API does not provide the load of the cells, which is
+// exported by the kpimon xapp collector from the KPM measurements.
+type xappMlbCollector struct {
+	collector
+}
+
+func init() {
+	MustRegister(CollectorType{
+		Name:        exporterConfig.ONOSXAPPMLB,
+		Description: "The mlb xapp load balancing parameters and cell individual offsets",
+		Factory: func(base Base) (Collector, error) {
+			return &xappMlbCollector{
+				collector: baseCollector(base),
+			}, nil
+		},
+	})
+}
+
+// Collect implements the Collector interface behavior for
+// XappMlbCollector, returning a list of kpis.KPI.
+func (col *xappMlbCollector) Collect() ([]kpis.KPI, error) {
+	kpis := []kpis.KPI{}
+
+	if len(col.config.getAddress()) == 0 {
+		return kpis, fmt.Errorf("XappMlbCollector Collect missing service address")
+	}
+
+	conn, err := col.Connect()
+	if err != nil {
+		return kpis, err
+	}
+	defer conn.Close()
+
+	paramsKPI, err := listMlbParams(conn)
+	if err != nil {
+		return kpis, err
+	}
+
+	ocnKPI, err := listMlbOcn(conn)
+	if err != nil {
+		return kpis, err
+	}
+
+	kpis = append(kpis, paramsKPI)
+	kpis = append(kpis, ocnKPI)
+
+	return kpis, err
+}
+
+// listMlbParams receives a connection to a mlb xapp service
+// to retrieve its load balancing parameters and store them according
+// to the data structure of the kpis.XappMlbParams KPI.
+func listMlbParams(conn *grpc.ClientConn) (kpis.KPI, error) {
+	paramsKPI := kpis.XappMlbParams()
+
+	request := mlbapi.GetMlbParamRequest{}
+	client := mlbapi.NewMlbClient(conn)
+	response, err := client.GetMlbParams(context.Background(), &request)
+	if err != nil {
+		return paramsKPI, err
+	}
+
+	paramsKPI.Params = kpis.MlbParams{
+		Interval:          float64(response.GetInterval()),
+		OverloadThreshold: float64(response.GetOverloadThreshold()),
+		TargetThreshold:   float64(response.GetTargetThreshold()),
+		DeltaOcn:          float64(response.GetDeltaOcn()),
+	}
+
+	return paramsKPI, nil
+}
+
+// listMlbOcn receives a connection to a mlb xapp service
+// to retrieve the cell individual offsets of each cell and
+// neighbor cell and store them according to the data structure
+// of the kpis.XappMlbOcn KPI.
+func listMlbOcn(conn *grpc.ClientConn) (kpis.KPI, error) {
+	ocnKPI := kpis.XappMlbOcn()
+
+	request := mlbapi.GetOcnRequest{}
+	client := mlbapi.NewMlbClient(conn)
+	response, err := client.GetOcn(context.Background(), &request)
+	if err != nil {
+		return ocnKPI, err
+	}
+
+	for cellID, record := range response.GetOcnMap() {
+		for neighborCellID, ocn := range record.GetOcnRecord() {
+			ocnKPI.Ocns = append(ocnKPI.Ocns, kpis.CellOcn{
+				CellID:         cellID,
+				NeighborCellID: neighborCellID,
+				Ocn:            float64(ocn),
+			})
+		}
+	}
+
+	return ocnKPI, nil
+}
@@ -8,16 +8,66 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	pciapi "github.com/onosproject/onos-api/go/onos/pci"
+	exporterConfig "github.com/onosproject/onos-exporter/pkg/config"
 	"github.com/onosproject/onos-exporter/pkg/kpis"
 	"google.golang.org/grpc"
 )
 
 // xappPciCollector is the onos xapp pci collector.
 // It extracts all the pci related kpis using the Collect method.
+// The history keeps the PCI changes of the cells across collections,
+// and legacyInfo enables the former cell info metric.
 type xappPciCollector struct {
 	collector
+	history    *pciHistory
+	legacyInfo bool
+}
+
+// Consts define the options of the pci xapp collector.
+const (
+	xappPciLegacyInfoKey    = "xapppci.legacy-info"
+	xappPciHistoryWindowKey = "xapppci.history-window"
+)
+
+func init() {
+	MustRegister(CollectorType{
+		Name:        exporterConfig.ONOSXAPPPCI,
+		Description: "The pci xapp cells and resolved conflicts",
+		Options: []OptionSchema{
+			{
+				Key:         xappPciLegacyInfoKey,
+				Description: "export the former onos_xapppci_info metric, valued by the cell EARFCN and labeled by its PCI and neighbors, along with onos_xapppci_cell_info",
+				Default:     "true",
+			},
+			{
+				Key:         xappPciHistoryWindowKey,
+				Description: "window after which the history of the cells no longer listed by the pci xapp is removed",
+				Default:     "1h",
+			},
+		},
+		Factory: func(base Base) (Collector, error) {
+			legacyInfo, err := strconv.ParseBool(base.Option(xappPciLegacyInfoKey))
+			if err != nil {
+				return nil, fmt.Errorf("invalid option %s: %s", xappPciLegacyInfoKey, err)
+			}
+			window, err := time.ParseDuration(base.Option(xappPciHistoryWindowKey))
+			if err == nil && window <= 0 {
+				err = fmt.Errorf("window %s must be positive", window)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid option %s: %s", xappPciHistoryWindowKey, err)
+			}
+			return &xappPciCollector{
+				collector:  baseCollector(base),
+				history:    newPciHistory(window),
+				legacyInfo: legacyInfo,
+			}, nil
+		},
+	})
 }
 
 // Collect implements the Collector interface behavior for
@@ -29,45 +79,47 @@ func (col *xappPciCollector) Collect() ([]kpis.KPI, error) {
 		return kpis, fmt.Errorf("XappPciCollector Collect missing service address")
 	}
 
-	conn, err := GetConnection(
-		col.config.getAddress(),
-		col.config.getCertPath(),
-		col.config.getKeyPath(),
-		col.config.noTLS(),
-	)
+	conn, err := col.Connect()
 	if err != nil {
 		return kpis, err
 	}
 	defer conn.Close()
 
-	cellInfoKPI, err := listCellInfo(conn)
+	cellInfoKPIs, err := listCellInfo(conn, col.history, col.legacyInfo)
 	if err != nil {
 		return kpis, err
 	}
 
-	conflictsKPI, err := listResolvedConflictsAll(conn)
+	conflictsKPI, err := listResolvedConflictsAll(conn, col.history)
 	if err != nil {
 		return kpis, err
 	}
 
-	kpis = append(kpis, cellInfoKPI)
+	kpis = append(kpis, cellInfoKPIs...)
 	kpis = append(kpis, conflictsKPI)
+	kpis = append(kpis, col.history.KPI())
 
 	return kpis, err
 }
 
 // listCellInfo receives a connection to a pci xapp service
 // to retrieve the pci conflicts and store them according to the
-// data structure of the kpis.XappPciNumConflicts KPI.
-func listCellInfo(conn *grpc.ClientConn) (kpis.KPI, error) {
+// data structure of the kpis.XappPciNumConflicts KPI. The same cells
+// are validated by the kpis.XappPciValidation KPI, and their PCIs
+// recorded by the history. Legacy enables the former cell info metric.
+func listCellInfo(conn *grpc.ClientConn, history *pciHistory, legacy bool) ([]kpis.KPI, error) {
 	numConflictsKPI := kpis.XappPciNumConflicts()
 	numConflictsKPI.Cells = make(map[string]kpis.CellInfo)
+	numConflictsKPI.Legacy = legacy
+
+	validationKPI := kpis.XappPciValidation()
+	validationKPI.Cells = numConflictsKPI.Cells
 
 	request := pciapi.GetConflictsRequest{}
 	client := pciapi.NewPciClient(conn)
 	response, err := client.GetConflicts(context.Background(), &request)
 	if err != nil {
-		return numConflictsKPI, err
+		return []kpis.KPI{numConflictsKPI, validationKPI}, err
 	}
 
 	for _, cell := range response.GetCells() {
@@ -80,19 +132,27 @@ func listCellInfo(conn *grpc.ClientConn) (kpis.KPI, error) {
 		cellDlearfcn := float64(cell.Dlearfcn)
 
 		neighbors := neighborsAsCSV(cell)
+		neighborIDs := make([]string, 0, len(cell.NeighborIds))
+		for _, neighbor := range cell.NeighborIds {
+			neighborIDs = append(neighborIDs, fmt.Sprintf("%x", neighbor))
+		}
 
 		cInfo := kpis.CellInfo{
 			CellID:        cellID,
 			NodeID:        nodeID,
 			CellType:      cellType,
 			CellPci:       cellPci,
+			Pci:           cell.Pci,
 			CellDlearfcn:  cellDlearfcn,
 			CellNeighbors: neighbors,
+			NeighborIDs:   neighborIDs,
 		}
 		numConflictsKPI.Cells[cellID] = cInfo
 	}
 
-	return numConflictsKPI, nil
+	history.updatePcis(numConflictsKPI.Cells, time.Now())
+
+	return []kpis.KPI{numConflictsKPI, validationKPI}, nil
 }
 
 func neighborsAsCSV(cell *pciapi.PciCell) string {
@@ -110,8 +170,9 @@ func neighborsAsCSV(cell *pciapi.PciCell) string {
 
 // listNumConflictsAll receives a connection to a pci xapp service
 // to retrieve the pci conflicts and store them according to the
-// data structure of the kpis.XappPciNumConflicts KPI.
-func listResolvedConflictsAll(conn *grpc.ClientConn) (kpis.KPI, error) {
+// data structure of the kpis.XappPciNumConflicts KPI. The resolved
+// conflicts are recorded by the history.
+func listResolvedConflictsAll(conn *grpc.ClientConn, history *pciHistory) (kpis.KPI, error) {
 	resolvedConflictsKPI := kpis.XappPciResolvedConflicts()
 	resolvedConflictsKPI.Cells = make(map[string]kpis.CellConflict)
 
@@ -134,5 +195,7 @@ func listResolvedConflictsAll(conn *grpc.ClientConn) (kpis.KPI, error) {
 		resolvedConflictsKPI.Cells[cellID] = cInfo
 	}
 
+	history.updateResolvedConflicts(resolvedConflictsKPI.Cells, time.Now())
+
 	return resolvedConflictsKPI, nil
 }
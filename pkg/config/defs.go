@@ -6,11 +6,17 @@ package config
 
 // Consts define the names of the available collector names.
 // It defines those names based on the collectors available
-// at the collect package.
+// at the collect package. Other collector types can be added
+// to the collect package registry (see collect.Register).
 const (
 	ONOSE2T        = "onos-e2t"
 	ONOSXAPPKPIMON = "onos-xappkpimon"
 	ONOSXAPPPCI    = "onos-xapppci"
+	ONOSXAPPMHO    = "onos-xappmho"
+	ONOSXAPPMLB    = "onos-xappmlb"
 	ONOSTOPO       = "onos-topo"
 	ONOSUENIB      = "onos-uenib"
+	ONOSCONFIG     = "onos-config"
+	ONOSRANSIM     = "onos-ransim"
+	ONOSGENERIC    = "onos-generic"
 )
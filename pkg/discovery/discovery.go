@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Consts define the kinds of Kubernetes resources a Selector
+// can discover endpoints from.
+const (
+	RolePod     = "pod"
+	RoleService = "service"
+)
+
+// Consts define the labels added to the metrics of
+// the collectors of discovered targets.
+const (
+	NamespaceLabel = "namespace"
+	PodLabel       = "pod"
+	ServiceLabel   = "service"
+)
+
+// Target defines an endpoint found by a Discoverer, that
+// must be scraped by a collector of type Type.
+type Target struct {
+	Type    string
+	Address string
+	Labels  map[string]string
+}
+
+// Key returns an unique identifier of a Target.
+func (t Target) Key() string {
+	names := make([]string, 0, len(t.Labels))
+	for name := range t.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	key := []string{t.Type, t.Address}
+	for _, name := range names {
+		key = append(key, fmt.Sprintf("%s=%s", name, t.Labels[name]))
+	}
+	return strings.Join(key, ",")
+}
+
+// Selector defines which Kubernetes resources of a Role are
+// the endpoints of collectors of type Type.
+// LabelSelector follows the Kubernetes label selector syntax
+// (e.g., "name=onos-e2t") and Port states the gRPC port
+// of the discovered endpoints.
+type Selector struct {
+	Type          string
+	Role          string
+	LabelSelector string
+	Port          int
+}
+
+// Discoverer defines the behavior expected from
+// the discovery of collector targets.
+type Discoverer interface {
+	Discover(ctx context.Context) ([]Target, error)
+}
@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// NewKubernetesClient creates a Kubernetes clientset from the
+// kubeconfig file path, or from the in cluster configuration
+// if the path is empty.
+func NewKubernetesClient(kubeconfig string) (kubernetes.Interface, error) {
+	var restConfig *rest.Config
+	var err error
+
+	if kubeconfig == "" {
+		restConfig, err = rest.InClusterConfig()
+	} else {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// kubernetesDiscoverer implements the Discoverer interface
+// listing the pods and services of a set of namespaces
+// through the Kubernetes API.
+type kubernetesDiscoverer struct {
+	client     kubernetes.Interface
+	namespaces []string
+	selectors  []Selector
+}
+
+// NewKubernetesDiscoverer creates a Discoverer of the targets
+// matching the selectors in the namespaces. If no namespace
+// is defined, all namespaces are searched.
+func NewKubernetesDiscoverer(client kubernetes.Interface, namespaces []string, selectors []Selector) Discoverer {
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	return &kubernetesDiscoverer{
+		client:     client,
+		namespaces: namespaces,
+		selectors:  selectors,
+	}
+}
+
+// Discover implements the Discoverer interface behavior for
+// kubernetesDiscoverer, returning the targets of all selectors.
+func (k *kubernetesDiscoverer) Discover(ctx context.Context) ([]Target, error) {
+	targets := []Target{}
+
+	for _, namespace := range k.namespaces {
+		for _, selector := range k.selectors {
+			var found []Target
+			var err error
+
+			switch selector.Role {
+			case RoleService:
+				found, err = k.discoverServices(ctx, namespace, selector)
+			case RolePod, "":
+				found, err = k.discoverPods(ctx, namespace, selector)
+			default:
+				err = fmt.Errorf("unknown discovery role %s", selector.Role)
+			}
+			if err != nil {
+				return targets, err
+			}
+			targets = append(targets, found...)
+		}
+	}
+
+	return targets, nil
+}
+
+// discoverPods lists the running and ready pods matching a selector,
+// returning one target per pod IP address.
+func (k *kubernetesDiscoverer) discoverPods(ctx context.Context, namespace string, selector Selector) ([]Target, error) {
+	targets := []Target{}
+
+	pods, err := k.client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.LabelSelector})
+	if err != nil {
+		return targets, err
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || pod.DeletionTimestamp != nil || !podReady(pod) {
+			continue
+		}
+
+		targets = append(targets, Target{
+			Type:    selector.Type,
+			Address: net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(selector.Port)),
+			Labels: map[string]string{
+				NamespaceLabel: pod.Namespace,
+				PodLabel:       pod.Name,
+			},
+		})
+	}
+
+	return targets, nil
+}
+
+// podReady checks if the PodReady condition of a pod is true.
+func podReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// discoverServices lists the services matching a selector, returning
+// one target per service DNS name.
+func (k *kubernetesDiscoverer) discoverServices(ctx context.Context, namespace string, selector Selector) ([]Target, error) {
+	targets := []Target{}
+
+	services, err := k.client.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.LabelSelector})
+	if err != nil {
+		return targets, err
+	}
+
+	for _, service := range services.Items {
+		host := fmt.Sprintf("%s.%s", service.Name, service.Namespace)
+
+		targets = append(targets, Target{
+			Type:    selector.Type,
+			Address: net.JoinHostPort(host, strconv.Itoa(selector.Port)),
+			Labels: map[string]string{
+				NamespaceLabel: service.Namespace,
+				ServiceLabel:   service.Name,
+			},
+		})
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newPod(namespace, name, app, ip string, phase corev1.PodPhase) *corev1.Pod {
+	return newPodReady(namespace, name, app, ip, phase, corev1.ConditionTrue)
+}
+
+func newPodReady(namespace, name, app, ip string, phase corev1.PodPhase, ready corev1.ConditionStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{"name": app},
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+			PodIP: ip,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: ready},
+			},
+		},
+	}
+}
+
+func newService(namespace, name, app string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{"name": app},
+		},
+	}
+}
+
+func newClient() *fake.Clientset {
+	objects := []runtime.Object{
+		newPod("site-a", "onos-e2t-0", "onos-e2t", "10.0.0.1", corev1.PodRunning),
+		newPod("site-b", "onos-e2t-0", "onos-e2t", "10.0.1.1", corev1.PodRunning),
+		newPod("site-a", "onos-e2t-1", "onos-e2t", "10.0.0.2", corev1.PodPending),
+		newPod("site-a", "onos-e2t-2", "onos-e2t", "", corev1.PodRunning),
+		newPodReady("site-a", "onos-e2t-3", "onos-e2t", "10.0.0.4", corev1.PodRunning, corev1.ConditionFalse),
+		newPod("site-a", "onos-topo-0", "onos-topo", "10.0.0.3", corev1.PodRunning),
+		newService("site-a", "onos-e2t", "onos-e2t"),
+		newService("site-b", "onos-e2t", "onos-e2t"),
+		newService("site-a", "onos-topo", "onos-topo"),
+	}
+	return fake.NewSimpleClientset(objects...)
+}
+
+func discover(t *testing.T, namespaces []string, selectors ...Selector) []Target {
+	targets, err := NewKubernetesDiscoverer(newClient(), namespaces, selectors).Discover(context.Background())
+	assert.NoError(t, err)
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Key() < targets[j].Key()
+	})
+	return targets
+}
+
+func TestDiscoverPods(t *testing.T) {
+	targets := discover(t, nil, Selector{
+		Type:          "onos-e2t",
+		Role:          RolePod,
+		LabelSelector: "name=onos-e2t",
+		Port:          5150,
+	})
+
+	assert.Equal(t, []Target{
+		{
+			Type:    "onos-e2t",
+			Address: "10.0.0.1:5150",
+			Labels:  map[string]string{NamespaceLabel: "site-a", PodLabel: "onos-e2t-0"},
+		},
+		{
+			Type:    "onos-e2t",
+			Address: "10.0.1.1:5150",
+			Labels:  map[string]string{NamespaceLabel: "site-b", PodLabel: "onos-e2t-0"},
+		},
+	}, targets)
+}
+
+func TestDiscoverDefaultRole(t *testing.T) {
+	targets := discover(t, nil, Selector{
+		Type:          "onos-topo",
+		LabelSelector: "name=onos-topo",
+		Port:          5150,
+	})
+
+	assert.Len(t, targets, 1)
+	assert.Equal(t, "10.0.0.3:5150", targets[0].Address)
+	assert.Equal(t, "onos-topo-0", targets[0].Labels[PodLabel])
+}
+
+func TestDiscoverServices(t *testing.T) {
+	targets := discover(t, nil, Selector{
+		Type:          "onos-e2t",
+		Role:          RoleService,
+		LabelSelector: "name=onos-e2t",
+		Port:          5150,
+	})
+
+	assert.Equal(t, []Target{
+		{
+			Type:    "onos-e2t",
+			Address: "onos-e2t.site-a:5150",
+			Labels:  map[string]string{NamespaceLabel: "site-a", ServiceLabel: "onos-e2t"},
+		},
+		{
+			Type:    "onos-e2t",
+			Address: "onos-e2t.site-b:5150",
+			Labels:  map[string]string{NamespaceLabel: "site-b", ServiceLabel: "onos-e2t"},
+		},
+	}, targets)
+}
+
+func TestDiscoverNamespaces(t *testing.T) {
+	targets := discover(t, []string{"site-b"},
+		Selector{Type: "onos-e2t", Role: RolePod, LabelSelector: "name=onos-e2t", Port: 5150},
+		Selector{Type: "onos-topo", Role: RoleService, LabelSelector: "name=onos-topo", Port: 5150},
+	)
+
+	assert.Len(t, targets, 1)
+	assert.Equal(t, "onos-e2t", targets[0].Type)
+	assert.Equal(t, "site-b", targets[0].Labels[NamespaceLabel])
+}
+
+func TestDiscoverSelectors(t *testing.T) {
+	targets := discover(t, []string{"site-a"},
+		Selector{Type: "onos-e2t", Role: RolePod, LabelSelector: "name=onos-e2t", Port: 5150},
+		Selector{Type: "onos-topo", Role: RoleService, LabelSelector: "name=onos-topo", Port: 5151},
+	)
+
+	assert.Len(t, targets, 2)
+	assert.Equal(t, "onos-e2t", targets[0].Type)
+	assert.Equal(t, "10.0.0.1:5150", targets[0].Address)
+	assert.Equal(t, "onos-topo", targets[1].Type)
+	assert.Equal(t, "onos-topo.site-a:5151", targets[1].Address)
+}
+
+func TestDiscoverPort(t *testing.T) {
+	targets := discover(t, []string{"site-a"}, Selector{
+		Type:          "onos-e2t",
+		Role:          RolePod,
+		LabelSelector: "name=onos-e2t",
+		Port:          6150,
+	})
+
+	assert.Len(t, targets, 1)
+	assert.Equal(t, "10.0.0.1:6150", targets[0].Address)
+}
+
+func TestDiscoverUnknownRole(t *testing.T) {
+	discoverer := NewKubernetesDiscoverer(newClient(), nil, []Selector{{
+		Type:          "onos-e2t",
+		Role:          "deployment",
+		LabelSelector: "name=onos-e2t",
+		Port:          5150,
+	}})
+
+	_, err := discoverer.Discover(context.Background())
+	assert.Error(t, err)
+}
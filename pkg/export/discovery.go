@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-exporter/pkg/collect"
+	"github.com/onosproject/onos-exporter/pkg/discovery"
+)
+
+const discoveryTimeout = 10 * time.Second
+
+// discoveredCollectors maintains one collector per target found
+// by a discovery.Discoverer, refreshing the targets at most once
+// per interval. Collectors of targets found again in a refresh
+// are kept, so their state is preserved.
+type discoveredCollectors struct {
+	discoverer  discovery.Discoverer
+	configs     map[string]CollectorConfig
+	interval    time.Duration
+	mu          sync.Mutex
+	lastRefresh time.Time
+	collectors  map[string]collect.Collector
+}
+
+func newDiscoveredCollectors(discoverer discovery.Discoverer, configs map[string]CollectorConfig, interval time.Duration) *discoveredCollectors {
+	return &discoveredCollectors{
+		discoverer: discoverer,
+		configs:    configs,
+		interval:   interval,
+		collectors: make(map[string]collect.Collector),
+	}
+}
+
+// Collectors returns the collectors of the discovered targets,
+// refreshing the targets if the discovery interval has elapsed.
+func (d *discoveredCollectors) Collectors() []collect.Collector {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.lastRefresh.IsZero() || time.Since(d.lastRefresh) >= d.interval {
+		d.refresh()
+	}
+
+	collectors := make([]collect.Collector, 0, len(d.collectors))
+	for _, collector := range d.collectors {
+		collectors = append(collectors, collector)
+	}
+	return collectors
+}
+
+// refresh discovers the current targets, creating collectors for the
+// new ones and removing the collectors of the vanished ones. If the
+// discovery fails, the previous collectors are kept until the next
+// refresh, so a failing discovery does not delay every collection.
+func (d *discoveredCollectors) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), discoveryTimeout)
+	defer cancel()
+
+	targets, err := d.discoverer.Discover(ctx)
+	d.lastRefresh = time.Now()
+	if err != nil {
+		log.Errorf("collectors discovery error %s", err)
+		return
+	}
+
+	collectors := make(map[string]collect.Collector)
+	for _, target := range targets {
+		key := target.Key()
+		if collector, ok := d.collectors[key]; ok {
+			collectors[key] = collector
+			continue
+		}
+
+		collectorConfig := d.configs[target.Type]
+		collectorConfig.ServiceAddress = target.Address
+
+		collector, err := collect.CreateCollector(target.Type, collectorConfig.options())
+		if err != nil {
+			log.Errorf("%s not added to collectors %s", key, err)
+			continue
+		}
+
+		labels := collectorConfig.labels(target.Type)
+		for name, value := range target.Labels {
+			labels[name] = value
+		}
+
+		log.Infof("Adding discovered collector %s", key)
+		collectors[key] = collect.WithLabels(collector, labels)
+	}
+
+	d.collectors = collectors
+}
@@ -4,12 +4,131 @@
 
 package export
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/onosproject/onos-exporter/pkg/collect"
+	"github.com/onosproject/onos-exporter/pkg/discovery"
+	"github.com/onosproject/onos-exporter/pkg/kpis"
+)
+
+// InstanceLabel is the label added to the metrics of a named collector
+// instance (see collect.InstanceLabel).
+const InstanceLabel = collect.InstanceLabel
+
+// instanceLabels are the collect.ExporterLabels identifying a single
+// collector instance, rather than its site (e.g., its namespace).
+var instanceLabels = []string{
+	InstanceLabel,
+	discovery.PodLabel,
+	discovery.ServiceLabel,
+}
+
 // CollectorConfig states the parameters that enables a Collector.
+// Type defines the collector type (e.g., config.ONOSXAPPKPIMON), if empty
+// the name of the collector instance is used as its type. Labels are
+// added to all the metrics of the collector instance (e.g., site=a).
+// Options define the values of the options specific to the collector
+// type (see collect.CollectorType).
+// AuthHeader, AuthTokenFile and the OIDC fields define the alternative
+// sources of the authorization header attached to the collector gRPC
+// calls, in that order of precedence. The OIDC client secret is read from
+// OIDCClientSecretFile when defined, instead of OIDCClientSecret.
 type CollectorConfig struct {
-	ServiceAddress string
-	CAPath         string
-	KeyPath        string
-	CertPath       string
+	Type                 string
+	Labels               map[string]string
+	Options              map[string]string
+	ServiceAddress       string
+	CAPath               string
+	KeyPath              string
+	CertPath             string
+	AuthHeader           string
+	AuthTokenFile        string
+	OIDCTokenURL         string
+	OIDCClientID         string
+	OIDCClientSecret     string
+	OIDCClientSecretFile string
+	OIDCScopes           string
+}
+
+// collectorType returns the collector type of the collector
+// instance named instanceName.
+func (c CollectorConfig) collectorType(instanceName string) string {
+	if c.Type == "" {
+		return instanceName
+	}
+	return c.Type
+}
+
+// labels returns the labels of the collector instance named instanceName.
+func (c CollectorConfig) labels(instanceName string) map[string]string {
+	labels := make(map[string]string)
+	for name, value := range c.Labels {
+		labels[name] = value
+	}
+	if instanceName != c.collectorType(instanceName) {
+		labels[InstanceLabel] = instanceName
+	}
+	return labels
+}
+
+// ValidateLabels returns an error if one of the Labels is not a valid
+// prometheus label name, or if it clashes with the labels added by the
+// exporter or with the labels of the kpis (see kpis.ReservedLabels).
+func (c CollectorConfig) ValidateLabels() error {
+	reserved := append([]string{}, collect.ExporterLabels...)
+	reserved = append(reserved, kpis.ReservedLabels...)
+	for name := range c.Labels {
+		if err := kpis.ValidateLabelName(name, reserved...); err != nil {
+			return fmt.Errorf("collector label error %s", err)
+		}
+	}
+	return nil
+}
+
+// options returns the non-empty parameters of a CollectorConfig
+// keyed by the collect package option names, along with the
+// collector type specific Options.
+func (c CollectorConfig) options() map[string]string {
+	all := map[string]string{
+		collect.AddressKey:          c.ServiceAddress,
+		collect.TLSKeyPathKey:       c.KeyPath,
+		collect.TLSCertPathKey:      c.CertPath,
+		collect.AuthHeaderKey:       c.AuthHeader,
+		collect.AuthTokenFileKey:    c.AuthTokenFile,
+		collect.OIDCTokenURLKey:     c.OIDCTokenURL,
+		collect.OIDCClientIDKey:     c.OIDCClientID,
+		collect.OIDCClientSecretKey: c.OIDCClientSecret,
+		collect.OIDCSecretFileKey:   c.OIDCClientSecretFile,
+		collect.OIDCScopesKey:       c.OIDCScopes,
+	}
+
+	opts := make(map[string]string)
+	for _, values := range []map[string]string{c.Options, all} {
+		for key, value := range values {
+			if value != "" {
+				opts[key] = value
+			}
+		}
+	}
+	return opts
+}
+
+// DiscoveryConfig states the parameters of the discovery of
+// collector targets via the Kubernetes API.
+// Kubeconfig defines the path of a kubeconfig file, if empty
+// the in cluster configuration is used.
+// Namespaces restricts the discovery to a set of namespaces, if empty
+// all namespaces are searched.
+// Selectors define which pods or services are the targets of each
+// collector type, and Interval how often targets are refreshed.
+type DiscoveryConfig struct {
+	Enabled    bool
+	Kubeconfig string
+	Namespaces []string
+	Interval   time.Duration
+	Selectors  []discovery.Selector
 }
 
 // Config establishes the fields needed for the instantiation of
@@ -22,6 +141,11 @@ type CollectorConfig struct {
 // a northbound implementation of needed certificates for an exporter.
 // The remaining fields define the needed data needed for the exporters,
 // those fields can be defined in their own structs if needed.
+// TopologyPath defines the path of the exporter endpoint serving the
+// topology (DOT, GraphML or Cytoscape JSON), if empty it is not served.
+// When Discovery is enabled, the collectors of the types defined by its
+// selectors are created per discovered target, using the CollectorsConfigs
+// of their type with the discovered service address.
 type Config struct {
 	Address           string
 	Path              string
@@ -29,7 +153,9 @@ type Config struct {
 	CAPath            string
 	KeyPath           string
 	CertPath          string
+	TopologyPath      string
 	CollectorsConfigs map[string]CollectorConfig
+	Discovery         DiscoveryConfig
 }
 
 // exporter defines the behavior expected from an exporter.
@@ -5,30 +5,25 @@
 package export
 
 import (
+	"net/http"
+	"sort"
+
 	"github.com/onosproject/onos-exporter/pkg/collect"
-	"github.com/onosproject/onos-exporter/pkg/config"
+	"github.com/onosproject/onos-exporter/pkg/discovery"
+	"github.com/onosproject/onos-exporter/pkg/kpis"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 	"github.com/onosproject/onos-lib-go/pkg/prom"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-var (
-	log = logging.GetLogger("export", "prom")
-
-	// All the names of collectors that prometheus exporter instantiates.
-	collectorNames = []string{
-		config.ONOSE2T,
-		config.ONOSXAPPKPIMON,
-		config.ONOSXAPPPCI,
-		config.ONOSTOPO,
-		config.ONOSUENIB,
-	}
-)
+var log = logging.GetLogger("export", "prom")
 
 // CollectorsPrometheus defines a prometheus collector
-// for all collectors.
+// for all collectors, the statically configured ones and,
+// if enabled, the discovered ones.
 type CollectorsPrometheus struct {
 	collectors []collect.Collector
+	discovered *discoveredCollectors
 }
 
 // Retrieve implements the method needed for a Collector interface
@@ -36,9 +31,13 @@ type CollectorsPrometheus struct {
 // CollectorsPrometheus and pass them to the ch channel using the
 // prometheus.Metric format.
 // The function collect.KPIs performs the collection of each collector
-// list of KPIs, and aggregates them in onosKPIs var.
+// list of KPIs, and aggregates them in onosKPIs var, along with the
+// consistency checks between them (see kpis.CheckConsistency), which
+// compare the kpis of the collector instances with the same labels,
+// except the ones identifying each instance (see instanceLabels).
 func (c *CollectorsPrometheus) Retrieve(ch chan<- prometheus.Metric) error {
-	onosKPIs := collect.KPIs(c.collectors)
+	onosKPIs := collect.KPIs(c.allCollectors())
+	onosKPIs = append(onosKPIs, kpis.CheckConsistency(onosKPIs, instanceLabels...)...)
 
 	for _, kpi := range onosKPIs {
 		promMetrics, err := kpi.PrometheusFormat()
@@ -55,45 +54,123 @@ func (c *CollectorsPrometheus) Retrieve(ch chan<- prometheus.Metric) error {
 	return nil
 }
 
+// allCollectors returns the statically configured collectors
+// along with the discovered ones.
+func (c *CollectorsPrometheus) allCollectors() []collect.Collector {
+	if c.discovered == nil {
+		return c.collectors
+	}
+	collectors := append([]collect.Collector{}, c.collectors...)
+	return append(collectors, c.discovered.Collectors()...)
+}
+
 // Defines the set of collector used to extract KPIs for
 // the prometheus exporter. Each collector implements the
 // prom.Collector interface behavior via the method Collect.
-func initCollectorsPrometheus(config Config) prom.Collector {
+// A collector is created for each one of the collector instances
+// in config.CollectorsConfigs. Named instances add the label
+// InstanceLabel, with the instance name, to all of their metrics.
+func initCollectorsPrometheus(config Config) *CollectorsPrometheus {
 	collectors := []collect.Collector{}
+	discovered := initDiscoveredCollectors(config)
+
+	instanceNames := make([]string, 0, len(config.CollectorsConfigs))
+	for instanceName := range config.CollectorsConfigs {
+		instanceNames = append(instanceNames, instanceName)
+	}
+	sort.Strings(instanceNames)
+
+	for _, instanceName := range instanceNames {
+		collectorConfig := config.CollectorsConfigs[instanceName]
+		collectorType := collectorConfig.collectorType(instanceName)
 
-	for _, collectorName := range collectorNames {
-		collectorConfig, ok := config.CollectorsConfigs[collectorName]
+		if _, ok := collect.LookupType(collectorType); !ok {
+			log.Errorf("%s not added to collectors unknown collector type %s", instanceName, collectorType)
+			continue
+		}
 
-		if ok {
-			collector, err := collect.CreateCollector(collectorName, collectorConfig.ServiceAddress)
+		if err := collectorConfig.ValidateLabels(); err != nil {
+			log.Errorf("%s not added to collectors %s", instanceName, err)
+			continue
+		}
 
-			if err != nil {
-				log.Errorf("%s not added to collectors %s", collectorName, err)
-			} else {
-				collectors = append(collectors, collector)
+		// The default instances of the discovered types are replaced by the
+		// discovered ones, or ignored if they have no address of their own.
+		if config.Discovery.Enabled && instanceName == collectorType && isDiscovered(config.Discovery, collectorType) {
+			if discovered != nil || collectorConfig.ServiceAddress == "" {
+				continue
 			}
+		}
 
-		} else {
-			log.Errorf("%s not added to collectors no configuration provided", collectorName)
+		collector, err := collect.CreateCollector(collectorType, collectorConfig.options())
+		if err != nil {
+			log.Errorf("%s not added to collectors %s", instanceName, err)
+			continue
+		}
+
+		collectors = append(collectors, collect.WithLabels(collector, collectorConfig.labels(instanceName)))
+	}
+
+	for _, colType := range collect.RegisteredTypes() {
+		if _, ok := config.CollectorsConfigs[colType.Name]; !ok {
+			log.Infof("%s default instance not added to collectors no configuration provided", colType.Name)
 		}
 	}
 
 	return &CollectorsPrometheus{
 		collectors: collectors,
+		discovered: discovered,
+	}
+}
+
+// initDiscoveredCollectors creates the discovered collectors if
+// discovery is enabled in config, returning nil otherwise or if
+// the Kubernetes client can not be created.
+func initDiscoveredCollectors(config Config) *discoveredCollectors {
+	if !config.Discovery.Enabled {
+		return nil
+	}
+
+	client, err := discovery.NewKubernetesClient(config.Discovery.Kubeconfig)
+	if err != nil {
+		log.Errorf("collectors discovery disabled, kubernetes client error %s", err)
+		return nil
+	}
+
+	discoverer := discovery.NewKubernetesDiscoverer(client, config.Discovery.Namespaces, config.Discovery.Selectors)
+	return newDiscoveredCollectors(discoverer, config.CollectorsConfigs, config.Discovery.Interval)
+}
+
+// isDiscovered checks if the targets of the collector type collectorName
+// are defined by a discovery selector.
+func isDiscovered(discoveryConfig DiscoveryConfig, collectorName string) bool {
+	for _, selector := range discoveryConfig.Selectors {
+		if selector.Type == collectorName {
+			return true
+		}
 	}
+	return false
 }
 
 // PrometheusExporter uses Config to create an instance of a
 // Prometheus exporter, registering all its collectors, which must
 // implement the interface method Retrieve.
+// If config.TopologyPath is set, the topology of its collectors
+// is served in that path too.
 func PrometheusExporter(config Config) prom.Exporter {
 	exporter := prom.NewExporter(config.Path, config.Address)
+	collectors := initCollectorsPrometheus(config)
 
 	log.Info("Registering collector sdran")
-	err := exporter.RegisterCollector("sdran", initCollectorsPrometheus(config))
+	err := exporter.RegisterCollector("sdran", collectors)
 	if err != nil {
 		log.Errorf("error registering collector sdran %s", err)
 	}
 
+	if config.TopologyPath != "" {
+		log.Infof("Serving topology in %s", config.TopologyPath)
+		http.Handle(config.TopologyPath, &topologyHandler{collectors: collectors.allCollectors})
+	}
+
 	return exporter
 }
@@ -0,0 +1,330 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/onosproject/onos-exporter/pkg/collect"
+)
+
+// Consts define the formats of the topology endpoint,
+// selected by its format query parameter.
+const (
+	TopologyFormatDOT       = "dot"
+	TopologyFormatGraphML   = "graphml"
+	TopologyFormatCytoscape = "json"
+)
+
+// topologyHandler serves the topology of the collectors providing one
+// (see collect.TopologyCollector) as a graph, merging the topologies of
+// multiple collectors. Relations referring to entities not in the
+// topology add those entities without kind, so every edge has its nodes.
+type topologyHandler struct {
+	collectors func() []collect.Collector
+}
+
+func (h *topologyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = TopologyFormatDOT
+	}
+
+	var encode func(io.Writer, collect.Topology) error
+	var contentType string
+	switch format {
+	case TopologyFormatDOT:
+		encode, contentType = encodeDOT, "text/vnd.graphviz; charset=utf-8"
+	case TopologyFormatGraphML:
+		encode, contentType = encodeGraphML, "application/graphml+xml; charset=utf-8"
+	case TopologyFormatCytoscape:
+		encode, contentType = encodeCytoscape, "application/json"
+	default:
+		http.Error(w, fmt.Sprintf("unknown topology format %s, must be %s, %s or %s",
+			format, TopologyFormatDOT, TopologyFormatGraphML, TopologyFormatCytoscape), http.StatusBadRequest)
+		return
+	}
+
+	topology, err := h.topology()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	if err := encode(w, topology); err != nil {
+		log.Errorf("topology %s encoding error %s", format, err)
+	}
+}
+
+// topology merges the topologies of the collectors, failing if
+// there is no collector providing one or none of them succeeds.
+func (h *topologyHandler) topology() (collect.Topology, error) {
+	topology := collect.Topology{}
+	found, succeeded := false, false
+	var lastErr error
+
+	for _, col := range h.collectors() {
+		topoCol, ok := collect.AsTopologyCollector(col)
+		if !ok {
+			continue
+		}
+		found = true
+
+		colTopology, err := topoCol.Topology()
+		if err != nil {
+			log.Errorf("topology collector error %s", err)
+			lastErr = err
+			continue
+		}
+		succeeded = true
+		topology.Entities = append(topology.Entities, colTopology.Entities...)
+		topology.Relations = append(topology.Relations, colTopology.Relations...)
+	}
+
+	if !found {
+		return topology, fmt.Errorf("no topology collector configured")
+	}
+	if !succeeded {
+		return topology, lastErr
+	}
+	return completeTopology(topology), nil
+}
+
+// completeTopology sorts the entities and relations of a topology by ID,
+// removing duplicates and adding the entities missing from relations.
+func completeTopology(topology collect.Topology) collect.Topology {
+	entities := make(map[string]collect.TopologyEntity)
+	for _, e := range topology.Entities {
+		entities[e.ID] = e
+	}
+	relations := make(map[string]collect.TopologyRelation)
+	for _, r := range topology.Relations {
+		relations[r.ID] = r
+		for _, id := range []string{r.Source, r.Target} {
+			if _, ok := entities[id]; !ok {
+				entities[id] = collect.TopologyEntity{ID: id}
+			}
+		}
+	}
+
+	complete := collect.Topology{}
+	for _, e := range entities {
+		complete.Entities = append(complete.Entities, e)
+	}
+	for _, r := range relations {
+		complete.Relations = append(complete.Relations, r)
+	}
+	sort.Slice(complete.Entities, func(i, j int) bool {
+		return complete.Entities[i].ID < complete.Entities[j].ID
+	})
+	sort.Slice(complete.Relations, func(i, j int) bool {
+		return complete.Relations[i].ID < complete.Relations[j].ID
+	})
+	return complete
+}
+
+// sortedKeys returns the keys of m sorted.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// labelsCSV returns the labels as a CSV sorted by key.
+func labelsCSV(labels map[string]string) string {
+	pairs := []string{}
+	for _, k := range sortedKeys(labels) {
+		pairs = append(pairs, k+"="+labels[k])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// aspectsJSON returns the aspects as a JSON object, keeping
+// the aspect values that are not valid JSON as strings.
+func aspectsJSON(aspects map[string]string) map[string]json.RawMessage {
+	values := make(map[string]json.RawMessage)
+	for aspectType, value := range aspects {
+		if json.Valid([]byte(value)) {
+			values[aspectType] = json.RawMessage(value)
+		} else {
+			quoted, _ := json.Marshal(value)
+			values[aspectType] = quoted
+		}
+	}
+	return values
+}
+
+// dotEscaper escapes the characters with a special meaning
+// within a DOT quoted string.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// dotQuote returns the DOT quoted string of the values, joined
+// by the DOT escaped newline (i.e., as lines of a label).
+func dotQuote(values ...string) string {
+	escaped := make([]string, 0, len(values))
+	for _, value := range values {
+		escaped = append(escaped, dotEscaper.Replace(value))
+	}
+	return `"` + strings.Join(escaped, `\n`) + `"`
+}
+
+// encodeDOT writes a topology in the Graphviz DOT format,
+// labeling nodes with the entity ID and kind and edges with
+// the relation kind.
+func encodeDOT(w io.Writer, topology collect.Topology) error {
+	var b strings.Builder
+	b.WriteString("digraph topology {\n")
+	for _, e := range topology.Entities {
+		aspects, _ := json.Marshal(aspectsJSON(e.Aspects))
+		fmt.Fprintf(&b, "  %s [label=%s, kind=%s, labels=%s, aspects=%s];\n",
+			dotQuote(e.ID), dotQuote(e.ID, e.Kind), dotQuote(e.Kind), dotQuote(labelsCSV(e.Labels)), dotQuote(string(aspects)))
+	}
+	for _, r := range topology.Relations {
+		fmt.Fprintf(&b, "  %s -> %s [id=%s, label=%s, kind=%s, labels=%s];\n",
+			dotQuote(r.Source), dotQuote(r.Target), dotQuote(r.ID), dotQuote(r.Kind), dotQuote(r.Kind), dotQuote(labelsCSV(r.Labels)))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// encodeGraphML writes a topology in the GraphML format, the kinds,
+// labels (CSV) and aspects (JSON) of the objects being node and
+// edge data.
+func encodeGraphML(w io.Writer, topology collect.Topology) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "kind", For: "all", AttrName: "kind", AttrType: "string"},
+			{ID: "labels", For: "all", AttrName: "labels", AttrType: "string"},
+			{ID: "aspects", For: "all", AttrName: "aspects", AttrType: "string"},
+		},
+		Graph: graphMLGraph{
+			ID:          "topology",
+			EdgeDefault: "directed",
+		},
+	}
+
+	data := func(kind string, labels, aspects map[string]string) []graphMLData {
+		aspectsValue, _ := json.Marshal(aspectsJSON(aspects))
+		return []graphMLData{
+			{Key: "kind", Value: kind},
+			{Key: "labels", Value: labelsCSV(labels)},
+			{Key: "aspects", Value: string(aspectsValue)},
+		}
+	}
+	for _, e := range topology.Entities {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{ID: e.ID, Data: data(e.Kind, e.Labels, e.Aspects)})
+	}
+	for _, r := range topology.Relations {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			ID:     r.ID,
+			Source: r.Source,
+			Target: r.Target,
+			Data:   data(r.Kind, r.Labels, r.Aspects),
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(doc)
+}
+
+type cytoscapeElement struct {
+	Data cytoscapeData `json:"data"`
+}
+
+type cytoscapeData struct {
+	ID      string                     `json:"id"`
+	Source  string                     `json:"source,omitempty"`
+	Target  string                     `json:"target,omitempty"`
+	Kind    string                     `json:"kind"`
+	Labels  map[string]string          `json:"labels,omitempty"`
+	Aspects map[string]json.RawMessage `json:"aspects,omitempty"`
+}
+
+// encodeCytoscape writes a topology in the Cytoscape.js JSON format.
+func encodeCytoscape(w io.Writer, topology collect.Topology) error {
+	doc := struct {
+		Elements struct {
+			Nodes []cytoscapeElement `json:"nodes"`
+			Edges []cytoscapeElement `json:"edges"`
+		} `json:"elements"`
+	}{}
+	doc.Elements.Nodes = []cytoscapeElement{}
+	doc.Elements.Edges = []cytoscapeElement{}
+
+	for _, e := range topology.Entities {
+		doc.Elements.Nodes = append(doc.Elements.Nodes, cytoscapeElement{Data: cytoscapeData{
+			ID:      e.ID,
+			Kind:    e.Kind,
+			Labels:  e.Labels,
+			Aspects: aspectsJSON(e.Aspects),
+		}})
+	}
+	for _, r := range topology.Relations {
+		doc.Elements.Edges = append(doc.Elements.Edges, cytoscapeElement{Data: cytoscapeData{
+			ID:      r.ID,
+			Source:  r.Source,
+			Target:  r.Target,
+			Kind:    r.Kind,
+			Labels:  r.Labels,
+			Aspects: aspectsJSON(r.Aspects),
+		}})
+	}
+
+	return json.NewEncoder(w).Encode(doc)
+}
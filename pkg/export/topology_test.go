@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/onosproject/onos-exporter/pkg/collect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDotQuote(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected string
+	}{
+		{
+			name:     "empty",
+			values:   []string{""},
+			expected: `""`,
+		},
+		{
+			name:     "plain",
+			values:   []string{"e2:1/5153"},
+			expected: `"e2:1/5153"`,
+		},
+		{
+			name:     "quotes",
+			values:   []string{`{"pci":1}`},
+			expected: `"{\"pci\":1}"`,
+		},
+		{
+			name:     "backslashes",
+			values:   []string{`a\b\"`},
+			expected: `"a\\b\\\""`,
+		},
+		{
+			name:     "lines",
+			values:   []string{"e2:1", `e2"node`},
+			expected: `"e2:1\ne2\"node"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, dotQuote(test.values...))
+		})
+	}
+}
+
+func TestCompleteTopology(t *testing.T) {
+	tests := []struct {
+		name     string
+		topology collect.Topology
+		expected collect.Topology
+	}{
+		{
+			name: "sorted",
+			topology: collect.Topology{
+				Entities: []collect.TopologyEntity{
+					{ID: "b", Kind: "e2cell"},
+					{ID: "a", Kind: "e2node"},
+				},
+				Relations: []collect.TopologyRelation{
+					{ID: "r2", Kind: "contains", Source: "a", Target: "b"},
+					{ID: "r1", Kind: "contains", Source: "a", Target: "b"},
+				},
+			},
+			expected: collect.Topology{
+				Entities: []collect.TopologyEntity{
+					{ID: "a", Kind: "e2node"},
+					{ID: "b", Kind: "e2cell"},
+				},
+				Relations: []collect.TopologyRelation{
+					{ID: "r1", Kind: "contains", Source: "a", Target: "b"},
+					{ID: "r2", Kind: "contains", Source: "a", Target: "b"},
+				},
+			},
+		},
+		{
+			name: "duplicates",
+			topology: collect.Topology{
+				Entities: []collect.TopologyEntity{
+					{ID: "a", Kind: "e2node"},
+					{ID: "a", Kind: "e2node"},
+				},
+				Relations: []collect.TopologyRelation{
+					{ID: "r1", Kind: "contains", Source: "a", Target: "a"},
+					{ID: "r1", Kind: "contains", Source: "a", Target: "a"},
+				},
+			},
+			expected: collect.Topology{
+				Entities: []collect.TopologyEntity{
+					{ID: "a", Kind: "e2node"},
+				},
+				Relations: []collect.TopologyRelation{
+					{ID: "r1", Kind: "contains", Source: "a", Target: "a"},
+				},
+			},
+		},
+		{
+			name: "missing entities",
+			topology: collect.Topology{
+				Entities: []collect.TopologyEntity{
+					{ID: "b", Kind: "e2cell"},
+				},
+				Relations: []collect.TopologyRelation{
+					{ID: "r1", Kind: "contains", Source: "a", Target: "b"},
+					{ID: "r2", Kind: "neighbors", Source: "b", Target: "c"},
+				},
+			},
+			expected: collect.Topology{
+				Entities: []collect.TopologyEntity{
+					{ID: "a"},
+					{ID: "b", Kind: "e2cell"},
+					{ID: "c"},
+				},
+				Relations: []collect.TopologyRelation{
+					{ID: "r1", Kind: "contains", Source: "a", Target: "b"},
+					{ID: "r2", Kind: "neighbors", Source: "b", Target: "c"},
+				},
+			},
+		},
+		{
+			name:     "empty",
+			topology: collect.Topology{},
+			expected: collect.Topology{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, completeTopology(test.topology))
+		})
+	}
+}
+
+func TestEncodeDOT(t *testing.T) {
+	topology := collect.Topology{
+		Entities: []collect.TopologyEntity{
+			{ID: "a", Kind: "e2node", Labels: map[string]string{"site": "x"}, Aspects: map[string]string{"onos.topo.E2Node": `{"pci":1}`}},
+			{ID: "b", Kind: "e2cell"},
+		},
+		Relations: []collect.TopologyRelation{
+			{ID: "r1", Kind: "contains", Source: "a", Target: "b"},
+		},
+	}
+
+	var b strings.Builder
+	assert.NoError(t, encodeDOT(&b, topology))
+	assert.Equal(t, `digraph topology {
+  "a" [label="a\ne2node", kind="e2node", labels="site=x", aspects="{\"onos.topo.E2Node\":{\"pci\":1}}"];
+  "b" [label="b\ne2cell", kind="e2cell", labels="", aspects="{}"];
+  "a" -> "b" [id="r1", label="contains", kind="contains", labels=""];
+}
+`, b.String())
+}
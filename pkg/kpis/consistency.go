@@ -0,0 +1,284 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpis
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/onosproject/onos-lib-go/pkg/prom"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Var definitions of consistency metrics builder and static labels.
+// builder is used to create metrics in the PrometheusFormat.
+var (
+	staticLabelsConsistency = map[string]string{"sdran": "consistency"}
+	consistencyBuilder      = prom.NewBuilder("onos", "consistency", staticLabelsConsistency)
+)
+
+// Consts define the consistency checks between the sources of kpis.
+const (
+	ConsistencyE2tNodeNotInTopo     = "e2t_node_not_in_topo"
+	ConsistencyTopoNodeNotConnected = "topo_node_not_connected"
+	ConsistencyUeCellNotInTopo      = "uenib_ue_cell_not_in_topo"
+	ConsistencyKpimonCellNotInTopo  = "kpimon_cell_not_in_topo"
+)
+
+// ConsistencyMismatch defines an object of a source (ID) not
+// consistent with another source, Reference being the ID of the
+// object it refers to, if any (e.g., the serving cell of a UE or
+// the e2 node of a kpimon cell).
+type ConsistencyMismatch struct {
+	Check     string
+	ID        string
+	Reference string
+}
+
+// consistency defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Checks are the consistency checks performed, whose Mismatches
+// are counted, so checks without mismatches count 0.
+type consistency struct {
+	name                  string
+	description           string
+	mismatchesName        string
+	mismatchesDescription string
+	Labels                []string
+	LabelValues           []string
+	Checks                []string
+	Mismatches            []ConsistencyMismatch
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for consistency.
+func (c *consistency) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	c.Labels = []string{"check", "id", "reference"}
+	mismatchDesc := consistencyBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsConsistency)
+	mismatchesDesc := consistencyBuilder.NewMetricDesc(c.mismatchesName, c.mismatchesDescription, []string{"check"}, staticLabelsConsistency)
+
+	counts := make(map[string]float64)
+	for _, check := range c.Checks {
+		counts[check] = 0
+	}
+	for _, mismatch := range c.Mismatches {
+		metric := consistencyBuilder.MustNewConstMetric(
+			mismatchDesc,
+			prometheus.GaugeValue,
+			1,
+			mismatch.Check,
+			mismatch.ID,
+			mismatch.Reference,
+		)
+		metrics = append(metrics, metric)
+		counts[mismatch.Check]++
+	}
+
+	for check, count := range counts {
+		metric := consistencyBuilder.MustNewConstMetric(mismatchesDesc, prometheus.GaugeValue, count, check)
+		metrics = append(metrics, metric)
+	}
+
+	return metrics, nil
+}
+
+// consistencySources defines the objects of the kpis of each source
+// compared by the consistency checks, the sources being present only
+// if any of their kpis was collected. The uenib source is present
+// only if the serving cell of its UEs was collected.
+type consistencySources struct {
+	topo        bool
+	topoNodes   map[string]bool
+	topoCells   map[string]string
+	e2t         bool
+	e2tNodes    map[string]bool
+	uenib       bool
+	ues         map[string]UE
+	kpimon      bool
+	kpimonCells map[string]KpimonData
+}
+
+func newConsistencySources() *consistencySources {
+	return &consistencySources{
+		topoNodes:   make(map[string]bool),
+		topoCells:   make(map[string]string),
+		e2tNodes:    make(map[string]bool),
+		ues:         make(map[string]UE),
+		kpimonCells: make(map[string]KpimonData),
+	}
+}
+
+// add adds the objects of a collected kpi to the sources.
+func (s *consistencySources) add(kpi KPI) {
+	switch k := kpi.(type) {
+	case *topoSummary:
+		s.topo = true
+		for nodeID := range k.Summary.NodeCells {
+			s.topoNodes[nodeID] = true
+		}
+		for cellID, entityID := range k.Summary.CellIDs {
+			s.topoCells[cellID] = entityID
+		}
+	case *onosE2tConnections:
+		s.e2t = true
+		for _, connection := range k.NumberConnections {
+			s.e2tNodes[connection.NodeId] = true
+		}
+	case *onosUenibUEs:
+		if !k.ServingCells {
+			break
+		}
+		s.uenib = true
+		for id, ue := range k.UEs {
+			s.ues[id] = ue
+		}
+	case *xappkpimon:
+		s.kpimon = true
+		for _, data := range k.Data {
+			s.kpimonCells[data.NodeID+"\x00"+kpimonCellID(data)] = data
+		}
+	}
+}
+
+// kpimonCellID returns the ID of a kpimon cell, its cell global ID
+// if it is known.
+func kpimonCellID(data KpimonData) string {
+	if data.CellGlobalID != "" {
+		return data.CellGlobalID
+	}
+	return data.CellID
+}
+
+// hasTopoCell returns whether any of the IDs identifies a topo cell.
+func (s *consistencySources) hasTopoCell(ids ...string) bool {
+	for _, id := range ids {
+		if _, ok := s.topoCells[id]; ok && id != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// unwrapLabels returns the KPI wrapped by kpi, if it is labeled, along
+// with its labels, except the ones named ignoredLabels.
+func unwrapLabels(kpi KPI, ignoredLabels []string) (KPI, map[string]string) {
+	labels := make(map[string]string)
+	for {
+		labeled, ok := kpi.(*labeledKPI)
+		if !ok {
+			break
+		}
+		for _, label := range labeled.labels {
+			labels[label.GetName()] = label.GetValue()
+		}
+		kpi = labeled.Unwrap()
+	}
+
+	for _, name := range ignoredLabels {
+		delete(labels, name)
+	}
+	return kpi, labels
+}
+
+// labelsKey returns a key identifying a set of labels.
+func labelsKey(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for name, value := range labels {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\x00")
+}
+
+// CheckConsistency compares the objects of the collected kpis of the
+// different sources, returning the kpis Consistency with the mismatches
+// between them: e2 nodes connected to e2t but missing from topo, topo
+// e2 nodes without e2t connection, uenib UEs whose serving cell is not
+// a topo cell and kpimon cells missing from topo. A check is performed
+// only if the kpis of both of its sources were collected, the serving
+// cell of the UEs being collected only if the uenib collector defines
+// its aspect (see option uenib.serving-cell-aspect).
+// The kpis are compared only with the ones having the same labels
+// (e.g., site=a), except the ignoredLabels identifying each collector
+// instance, and those labels are added to the kpi Consistency of each
+// group of kpis.
+func CheckConsistency(collected []KPI, ignoredLabels ...string) []KPI {
+	groups := make(map[string]*consistencySources)
+	groupLabels := make(map[string]map[string]string)
+	for _, kpi := range collected {
+		kpi, labels := unwrapLabels(kpi, ignoredLabels)
+		key := labelsKey(labels)
+		if _, ok := groups[key]; !ok {
+			groups[key] = newConsistencySources()
+			groupLabels[key] = labels
+		}
+		groups[key].add(kpi)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	consistencyKPIs := []KPI{}
+	for _, key := range keys {
+		if !groups[key].topo {
+			continue
+		}
+		consistencyKPIs = append(consistencyKPIs, WithLabels(groups[key].check(), groupLabels[key]))
+	}
+	return consistencyKPIs
+}
+
+// check performs the consistency checks between the sources, returning
+// a kpi Consistency with their mismatches.
+func (s *consistencySources) check() *consistency {
+	consistencyKPI := Consistency()
+
+	mismatch := func(check, id, reference string) {
+		consistencyKPI.Mismatches = append(consistencyKPI.Mismatches, ConsistencyMismatch{
+			Check:     check,
+			ID:        id,
+			Reference: reference,
+		})
+	}
+
+	if s.e2t {
+		consistencyKPI.Checks = append(consistencyKPI.Checks, ConsistencyE2tNodeNotInTopo, ConsistencyTopoNodeNotConnected)
+		for nodeID := range s.e2tNodes {
+			if !s.topoNodes[nodeID] {
+				mismatch(ConsistencyE2tNodeNotInTopo, nodeID, "")
+			}
+		}
+		for nodeID := range s.topoNodes {
+			if !s.e2tNodes[nodeID] {
+				mismatch(ConsistencyTopoNodeNotConnected, nodeID, "")
+			}
+		}
+	}
+
+	if s.uenib {
+		consistencyKPI.Checks = append(consistencyKPI.Checks, ConsistencyUeCellNotInTopo)
+		for id, ue := range s.ues {
+			if ue.ServingCell != "" && !s.hasTopoCell(ue.ServingCell) {
+				mismatch(ConsistencyUeCellNotInTopo, id, ue.ServingCell)
+			}
+		}
+	}
+
+	if s.kpimon {
+		consistencyKPI.Checks = append(consistencyKPI.Checks, ConsistencyKpimonCellNotInTopo)
+		for _, cell := range s.kpimonCells {
+			if !s.hasTopoCell(cell.CellGlobalID, cell.CellID, cell.NodeID+"/"+cell.CellGlobalID) {
+				mismatch(ConsistencyKpimonCellNotInTopo, kpimonCellID(cell), cell.NodeID)
+			}
+		}
+	}
+
+	return consistencyKPI
+}
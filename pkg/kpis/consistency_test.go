@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func topoKPI(labels map[string]string, nodeIDs []string, cellIDs ...string) KPI {
+	topoKPI := OnosTopoSummary()
+	topoKPI.Summary.NodeCells = make(map[string]float64)
+	topoKPI.Summary.CellIDs = make(map[string]string)
+	for _, nodeID := range nodeIDs {
+		topoKPI.Summary.NodeCells[nodeID] = 1
+	}
+	for _, cellID := range cellIDs {
+		topoKPI.Summary.CellIDs[cellID] = cellID
+	}
+	return WithLabels(topoKPI, labels)
+}
+
+func e2tKPI(labels map[string]string, nodeIDs ...string) KPI {
+	e2tKPI := OnosE2tConnections()
+	e2tKPI.NumberConnections = make(map[string]E2tConnection)
+	for _, nodeID := range nodeIDs {
+		e2tKPI.NumberConnections[nodeID] = E2tConnection{Id: nodeID, NodeId: nodeID}
+	}
+	return WithLabels(e2tKPI, labels)
+}
+
+func uenibKPI(labels map[string]string, servingCells bool, ueCells map[string]string) KPI {
+	uenibKPI := OnosUenibUEs()
+	uenibKPI.ServingCells = servingCells
+	uenibKPI.UEs = make(map[string]UE)
+	for ueID, cellID := range ueCells {
+		uenibKPI.UEs[ueID] = UE{ID: ueID, ServingCell: cellID}
+	}
+	return WithLabels(uenibKPI, labels)
+}
+
+func TestCheckConsistency(t *testing.T) {
+	tests := []struct {
+		name      string
+		collected []KPI
+		expected  map[string]float64
+	}{
+		{
+			name: "no topo",
+			collected: []KPI{
+				e2tKPI(nil, "n1"),
+			},
+			expected: map[string]float64{},
+		},
+		{
+			name: "topo only",
+			collected: []KPI{
+				topoKPI(nil, []string{"n1"}),
+			},
+			expected: map[string]float64{},
+		},
+		{
+			name: "e2t nodes",
+			collected: []KPI{
+				topoKPI(nil, []string{"n1", "n2"}),
+				e2tKPI(nil, "n1", "n3"),
+			},
+			expected: map[string]float64{
+				`onos_consistency_mismatch{check="e2t_node_not_in_topo",id="n3",reference=""}`:    1,
+				`onos_consistency_mismatch{check="topo_node_not_connected",id="n2",reference=""}`: 1,
+				`onos_consistency_mismatches{check="e2t_node_not_in_topo"}`:                       1,
+				`onos_consistency_mismatches{check="topo_node_not_connected"}`:                    1,
+			},
+		},
+		{
+			name: "instances grouped by the other labels",
+			collected: []KPI{
+				topoKPI(map[string]string{"onos_instance": "topo-a", "site": "a"}, []string{"n1"}),
+				e2tKPI(map[string]string{"onos_instance": "e2t-a", "pod": "onos-e2t-0", "site": "a"}, "n1", "n2"),
+				topoKPI(map[string]string{"onos_instance": "topo-b", "site": "b"}, []string{"n2"}),
+				e2tKPI(map[string]string{"onos_instance": "e2t-b", "site": "b"}, "n2"),
+			},
+			expected: map[string]float64{
+				`onos_consistency_mismatch{check="e2t_node_not_in_topo",id="n2",reference="",site="a"}`: 1,
+				`onos_consistency_mismatches{check="e2t_node_not_in_topo",site="a"}`:                    1,
+				`onos_consistency_mismatches{check="topo_node_not_connected",site="a"}`:                 0,
+				`onos_consistency_mismatches{check="e2t_node_not_in_topo",site="b"}`:                    0,
+				`onos_consistency_mismatches{check="topo_node_not_connected",site="b"}`:                 0,
+			},
+		},
+		{
+			name: "sources with other labels not compared",
+			collected: []KPI{
+				topoKPI(map[string]string{"site": "a"}, []string{"n1"}),
+				e2tKPI(map[string]string{"site": "b"}, "n2"),
+			},
+			expected: map[string]float64{},
+		},
+		{
+			name: "uenib serving cells",
+			collected: []KPI{
+				topoKPI(nil, nil, "c1"),
+				uenibKPI(nil, true, map[string]string{"ue1": "c1", "ue2": "c2", "ue3": ""}),
+			},
+			expected: map[string]float64{
+				`onos_consistency_mismatch{check="uenib_ue_cell_not_in_topo",id="ue2",reference="c2"}`: 1,
+				`onos_consistency_mismatches{check="uenib_ue_cell_not_in_topo"}`:                       1,
+			},
+		},
+		{
+			name: "uenib without serving cells",
+			collected: []KPI{
+				topoKPI(nil, nil, "c1"),
+				uenibKPI(nil, false, map[string]string{"ue1": "c2"}),
+			},
+			expected: map[string]float64{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values := make(map[string]float64)
+			for _, kpi := range CheckConsistency(test.collected, "onos_instance", "pod", "service") {
+				for key, value := range metricValues(t, kpi) {
+					values[key] = value
+				}
+			}
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
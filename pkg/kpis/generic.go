@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpis
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/onosproject/onos-lib-go/pkg/prom"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Consts define the metric types of a GenericSample.
+const (
+	GenericGauge   = "gauge"
+	GenericCounter = "counter"
+)
+
+// ReservedSubsystems are the subsystems of the metrics of the KPIs of
+// this package, which can not be the subsystem of a genericMetrics.
+var ReservedSubsystems = []string{
+	"config", "consistency", "e2t", "ransim", "topo", "uenib",
+	"xappkpimon", "xappmho", "xappmlb", "xapppci",
+}
+
+// GenericSample defines a sample of a metric extracted by the
+// generic collector, identified by its name and label values.
+type GenericSample struct {
+	Name        string
+	Help        string
+	Type        string
+	Labels      []string
+	LabelValues []string
+	Value       float64
+}
+
+// Key returns an unique identifier of a GenericSample.
+func (s GenericSample) Key() string {
+	return s.Name + "{" + strings.Join(s.LabelValues, ",") + "}"
+}
+
+// genericMetrics defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Samples stores the samples extracted by the generic collector,
+// exported under the subsystem.
+type genericMetrics struct {
+	name        string
+	description string
+	subsystem   string
+	Samples     map[string]GenericSample
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for genericMetrics.
+func (g *genericMetrics) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	staticLabels := map[string]string{StaticLabel: g.subsystem}
+	builder := prom.NewBuilder("onos", g.subsystem, staticLabels)
+
+	keys := make([]string, 0, len(g.Samples))
+	for key := range g.Samples {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		sample := g.Samples[key]
+
+		help := sample.Help
+		if help == "" {
+			help = g.description
+		}
+
+		valueType := prometheus.GaugeValue
+		if sample.Type == GenericCounter {
+			valueType = prometheus.CounterValue
+		}
+
+		metricDesc := builder.NewMetricDesc(sample.Name, help, sample.Labels, map[string]string{})
+		metric, err := prometheus.NewConstMetric(metricDesc, valueType, sample.Value, sample.LabelValues...)
+		if err != nil {
+			return metrics, err
+		}
+		metrics = append(metrics, metric)
+	}
+
+	return metrics, nil
+}
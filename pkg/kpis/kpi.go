@@ -18,10 +18,109 @@ type KPI interface {
 // Name and description are used to define a particular KPI.
 const (
 	onosE2tConnectionsKPIName        = "connections"
-	onosE2tConnectionsKPIDescription = "The number of e2t connections"
+	onosE2tConnectionsKPIDescription = "The e2t connections, one series per connection"
+
+	onosE2tConnectionsCountKPIName        = "connections_count"
+	onosE2tConnectionsCountKPIDescription = "The number of e2t connections"
+
+	onosE2tConnectionsByKPIName        = "connections_by_%s"
+	onosE2tConnectionsByKPIDescription = "The number of e2t connections by %s"
+
+	onosE2tConnectionAgeKPIName        = "connection_age_seconds"
+	onosE2tConnectionAgeKPIDescription = "The age of an e2t connection in seconds"
+
+	onosE2tNodeConnectsKPIName        = "node_connects_total"
+	onosE2tNodeConnectsKPIDescription = "The number of connects of an e2 node seen by the exporter"
+
+	onosE2tNodeDisconnectsKPIName        = "node_disconnects_total"
+	onosE2tNodeDisconnectsKPIDescription = "The number of disconnects of an e2 node seen by the exporter"
+
+	onosE2tNodeFlappingKPIName        = "node_flapping"
+	onosE2tNodeFlappingKPIDescription = "Whether an e2 node connects and disconnects repeatedly within the flap window"
+
+	onosE2tRanFunctionNodesKPIName        = "ran_function_nodes"
+	onosE2tRanFunctionNodesKPIDescription = "The number of e2 nodes advertising a RAN function OID"
+
+	onosE2tServiceModelsKPIName        = "service_model_info"
+	onosE2tServiceModelsKPIDescription = "The service model plugins registered in e2t"
+
+	onosE2tSubscriptionsKPIName        = "subscriptions"
+	onosE2tSubscriptionsKPIDescription = "The number of e2t subscriptions per node, service model and lifecycle state"
+
+	onosE2tChannelsKPIName        = "channels"
+	onosE2tChannelsKPIDescription = "The number of e2t subscription channels per xapp, node, service model and lifecycle state"
 
 	xappPciNumConflictsKPIName     = "info"
-	xappPciNumConflictsDescription = "The xapp pci cell info"
+	xappPciNumConflictsDescription = "The xapp pci cell info valued by the cell EARFCN (deprecated, see cell_info)"
+
+	xappPciCellInfoKPIName     = "cell_info"
+	xappPciCellInfoDescription = "The xapp pci cell info"
+
+	xappPciCellPciKPIName     = "cell_pci"
+	xappPciCellPciDescription = "The PCI of a xapp pci cell"
+
+	xappPciCellDlearfcnKPIName     = "cell_dlearfcn"
+	xappPciCellDlearfcnDescription = "The downlink EARFCN of a xapp pci cell"
+
+	xappPciNeighborKPIName     = "cell_neighbor"
+	xappPciNeighborDescription = "The xapp pci cell neighbor relations"
+
+	xappPciNeighborsKPIName     = "cell_neighbors"
+	xappPciNeighborsDescription = "The xapp pci number of neighbors of a cell"
+
+	xappPciAsymmetricKPIName     = "cell_neighbor_asymmetric"
+	xappPciAsymmetricDescription = "Whether the neighbor of a xapp pci cell relation does not list the cell as its neighbor"
+
+	xappPciCollisionsKPIName     = "cell_collisions"
+	xappPciCollisionsDescription = "The number of neighbors of a xapp pci cell having its PCI on its EARFCN"
+
+	xappPciConfusionsKPIName     = "cell_confusions"
+	xappPciConfusionsDescription = "The number of neighbors of a xapp pci cell sharing PCI with another one of its neighbors on an EARFCN"
+
+	xappPciCollisionCellsKPIName     = "collision_cells"
+	xappPciCollisionCellsDescription = "The number of xapp pci cells with PCI collisions on an EARFCN"
+
+	xappPciConfusionCellsKPIName     = "confusion_cells"
+	xappPciConfusionCellsDescription = "The number of xapp pci cells with PCI confusions on an EARFCN"
+
+	xappPciChangesKPIName     = "cell_pci_changes_total"
+	xappPciChangesDescription = "The number of PCI changes of a xapp pci cell seen by the exporter"
+
+	xappPciResolvedTotalKPIName     = "cell_resolved_conflicts_total"
+	xappPciResolvedTotalDescription = "The number of conflicts resolved for a xapp pci cell seen by the exporter"
+
+	xappPciPreviousPciKPIName     = "cell_previous_pci"
+	xappPciPreviousPciDescription = "The PCI of a xapp pci cell before its last change"
+
+	xappPciLastChangeKPIName     = "cell_pci_last_change_timestamp_seconds"
+	xappPciLastChangeDescription = "The time of the last PCI change of a xapp pci cell in seconds since epoch"
+
+	xappMhoA3OffsetKPIName     = "a3_offset"
+	xappMhoA3OffsetDescription = "The A3 event offset used by the xapp mho to trigger handovers"
+
+	xappMhoHysteresisKPIName     = "hysteresis"
+	xappMhoHysteresisDescription = "The A3 event hysteresis used by the xapp mho to trigger handovers"
+
+	xappMhoTimeToTriggerKPIName     = "time_to_trigger"
+	xappMhoTimeToTriggerDescription = "The A3 event time to trigger used by the xapp mho to trigger handovers"
+
+	xappMlbIntervalKPIName     = "interval"
+	xappMlbIntervalDescription = "The interval between load balancing runs of the xapp mlb"
+
+	xappMlbOverloadThresholdKPIName     = "overload_threshold"
+	xappMlbOverloadThresholdDescription = "The load threshold above which the xapp mlb considers a cell overloaded"
+
+	xappMlbTargetThresholdKPIName     = "target_threshold"
+	xappMlbTargetThresholdDescription = "The load threshold below which the xapp mlb considers a cell a target to offload"
+
+	xappMlbDeltaOcnKPIName     = "delta_ocn"
+	xappMlbDeltaOcnDescription = "The step by which the xapp mlb changes the cell individual offsets"
+
+	xappMlbOcnKPIName     = "cell_ocn"
+	xappMlbOcnDescription = "The cell individual offset set by the xapp mlb for a neighbor of a cell"
+
+	xappMlbOcnNeighborsKPIName     = "cell_ocn_neighbors"
+	xappMlbOcnNeighborsDescription = "The number of neighbors with a cell individual offset set by the xapp mlb for a cell"
 
 	xappPciResolvedConflictsKPIName     = "conflicts"
 	xappPciResolvedConflictsDescription = "The xapp pci resolved cell conflicts"
@@ -35,16 +134,139 @@ const (
 	topoRelationsKPIName        = "relations"
 	topoRelationsKPIDescription = "The onos topo relations"
 
+	topoAspectsKPIName        = "aspect"
+	topoAspectsKPIDescription = "The onos topo entity aspects"
+
+	topoSummaryKPIName        = "summary"
+	topoSummaryKPIDescription = "The onos topo summary"
+
 	OnosUenibUEsKPIName        = "aspects"
 	OnosUenibUEsKPIDescription = "The uenib aspects "
+
+	onosConfigModelsKPIName        = "model_plugin_info"
+	onosConfigModelsKPIDescription = "The model plugins registered in onos config"
+
+	onosConfigNetworkChangesKPIName        = "network_changes"
+	onosConfigNetworkChangesKPIDescription = "The number of onos config network changes per phase and state"
+
+	onosConfigDeviceChangesKPIName        = "device_changes"
+	onosConfigDeviceChangesKPIDescription = "The number of onos config device changes per target, phase and state"
+
+	onosConfigTargetsKPIName        = "target_info"
+	onosConfigTargetsKPIDescription = "The targets configured by onos config network changes"
+
+	onosRansimNodesKPIName        = "node_info"
+	onosRansimNodesKPIDescription = "The ransim simulated E2 nodes"
+
+	onosRansimNodeCellsKPIName        = "node_cells"
+	onosRansimNodeCellsKPIDescription = "The number of cells of a ransim simulated E2 node"
+
+	onosRansimCellsKPIName        = "cell_info"
+	onosRansimCellsKPIDescription = "The ransim simulated cells"
+
+	onosRansimCellPciKPIName        = "cell_pci"
+	onosRansimCellPciKPIDescription = "The PCI of a ransim simulated cell"
+
+	onosRansimCellEarfcnKPIName        = "cell_earfcn"
+	onosRansimCellEarfcnKPIDescription = "The EARFCN of a ransim simulated cell"
+
+	onosRansimCellMaxUEsKPIName        = "cell_max_ues"
+	onosRansimCellMaxUEsKPIDescription = "The maximum number of UEs of a ransim simulated cell"
+
+	onosRansimCellUEsKPIName        = "cell_ues"
+	onosRansimCellUEsKPIDescription = "The number of ransim simulated UEs served by a cell"
+
+	onosRansimCellRrcConnectedKPIName        = "cell_rrc_connected_ues"
+	onosRansimCellRrcConnectedKPIDescription = "The number of RRC connected UEs of a ransim simulated cell"
+
+	onosRansimCellRrcIdleKPIName        = "cell_rrc_idle_ues"
+	onosRansimCellRrcIdleKPIDescription = "The number of RRC idle UEs of a ransim simulated cell"
+
+	onosRansimUEsKPIName        = "ue_serving_cell"
+	onosRansimUEsKPIDescription = "The serving cell of a ransim simulated UE"
+
+	onosRansimUEServingRsrpKPIName        = "ue_serving_rsrp"
+	onosRansimUEServingRsrpKPIDescription = "The RSRP of the serving cell measured by a ransim simulated UE"
+
+	onosRansimUENeighborRsrpKPIName        = "ue_neighbor_rsrp"
+	onosRansimUENeighborRsrpKPIDescription = "The RSRP of a neighbor cell measured by a ransim simulated UE"
+
+	onosRansimUELatitudeKPIName        = "ue_latitude"
+	onosRansimUELatitudeKPIDescription = "The latitude of a ransim simulated UE"
+
+	onosRansimUELongitudeKPIName        = "ue_longitude"
+	onosRansimUELongitudeKPIDescription = "The longitude of a ransim simulated UE"
+
+	onosRansimRoutesKPIName        = "route_progress"
+	onosRansimRoutesKPIDescription = "The ratio of the waypoints reached by a ransim simulated UE in its route"
+
+	onosRansimRouteWaypointsKPIName        = "route_waypoints"
+	onosRansimRouteWaypointsKPIDescription = "The number of waypoints of the route of a ransim simulated UE"
+
+	consistencyKPIName        = "mismatch"
+	consistencyKPIDescription = "An object of a source not consistent with another source"
+
+	consistencyMismatchesKPIName        = "mismatches"
+	consistencyMismatchesKPIDescription = "The number of objects of a source not consistent with another source per check"
+
+	genericMetricsKPIName        = "generic"
+	genericMetricsKPIDescription = "The metrics extracted by the generic collector"
 )
 
 // OnosE2tConnections defines the factory implementation of a kpi
 // onosE2tConnections having a well defined name and description.
 func OnosE2tConnections() *onosE2tConnections {
 	return &onosE2tConnections{
-		name:        onosE2tConnectionsKPIName,
-		description: onosE2tConnectionsKPIDescription,
+		name:                    onosE2tConnectionsKPIName,
+		description:             onosE2tConnectionsKPIDescription,
+		countName:               onosE2tConnectionsCountKPIName,
+		countDescription:        onosE2tConnectionsCountKPIDescription,
+		countByName:             onosE2tConnectionsByKPIName,
+		countByDescription:      onosE2tConnectionsByKPIDescription,
+		ageName:                 onosE2tConnectionAgeKPIName,
+		ageDescription:          onosE2tConnectionAgeKPIDescription,
+		ranFunctionsName:        onosE2tRanFunctionNodesKPIName,
+		ranFunctionsDescription: onosE2tRanFunctionNodesKPIDescription,
+	}
+}
+
+// OnosE2tNodeLifecycle defines the factory implementation of a kpi
+// onosE2tNodeLifecycle having a well defined name and description.
+func OnosE2tNodeLifecycle() *onosE2tNodeLifecycle {
+	return &onosE2tNodeLifecycle{
+		name:                   onosE2tNodeConnectsKPIName,
+		description:            onosE2tNodeConnectsKPIDescription,
+		disconnectsName:        onosE2tNodeDisconnectsKPIName,
+		disconnectsDescription: onosE2tNodeDisconnectsKPIDescription,
+		flappingName:           onosE2tNodeFlappingKPIName,
+		flappingDescription:    onosE2tNodeFlappingKPIDescription,
+	}
+}
+
+// OnosE2tServiceModels defines the factory implementation of a kpi
+// onosE2tServiceModels having a well defined name and description.
+func OnosE2tServiceModels() *onosE2tServiceModels {
+	return &onosE2tServiceModels{
+		name:        onosE2tServiceModelsKPIName,
+		description: onosE2tServiceModelsKPIDescription,
+	}
+}
+
+// OnosE2tSubscriptions defines the factory implementation of a kpi
+// onosE2tSubscriptions having a well defined name and description.
+func OnosE2tSubscriptions() *onosE2tSubscriptions {
+	return &onosE2tSubscriptions{
+		name:        onosE2tSubscriptionsKPIName,
+		description: onosE2tSubscriptionsKPIDescription,
+	}
+}
+
+// OnosE2tChannels defines the factory implementation of a kpi
+// onosE2tChannels having a well defined name and description.
+func OnosE2tChannels() *onosE2tChannels {
+	return &onosE2tChannels{
+		name:        onosE2tChannelsKPIName,
+		description: onosE2tChannelsKPIDescription,
 	}
 }
 
@@ -57,12 +279,93 @@ func XappKpiMon() *xappkpimon {
 	}
 }
 
+// XappMhoParams defines the factory implementation of a kpi
+// xappMhoParams having a well defined name and description.
+func XappMhoParams() *xappMhoParams {
+	return &xappMhoParams{
+		name:                     xappMhoA3OffsetKPIName,
+		description:              xappMhoA3OffsetDescription,
+		hysteresisName:           xappMhoHysteresisKPIName,
+		hysteresisDescription:    xappMhoHysteresisDescription,
+		timeToTriggerName:        xappMhoTimeToTriggerKPIName,
+		timeToTriggerDescription: xappMhoTimeToTriggerDescription,
+	}
+}
+
+// XappMlbParams defines the factory implementation of a kpi
+// xappMlbParams having a well defined name and description.
+func XappMlbParams() *xappMlbParams {
+	return &xappMlbParams{
+		name:                         xappMlbIntervalKPIName,
+		description:                  xappMlbIntervalDescription,
+		overloadThresholdName:        xappMlbOverloadThresholdKPIName,
+		overloadThresholdDescription: xappMlbOverloadThresholdDescription,
+		targetThresholdName:          xappMlbTargetThresholdKPIName,
+		targetThresholdDescription:   xappMlbTargetThresholdDescription,
+		deltaOcnName:                 xappMlbDeltaOcnKPIName,
+		deltaOcnDescription:          xappMlbDeltaOcnDescription,
+	}
+}
+
+// XappMlbOcn defines the factory implementation of a kpi
+// xappMlbOcn having a well defined name and description.
+func XappMlbOcn() *xappMlbOcn {
+	return &xappMlbOcn{
+		name:                 xappMlbOcnKPIName,
+		description:          xappMlbOcnDescription,
+		neighborsName:        xappMlbOcnNeighborsKPIName,
+		neighborsDescription: xappMlbOcnNeighborsDescription,
+	}
+}
+
 // XappPciNumConflicts defines the factory implementation of a kpi
 // xappPciNumConflicts having a well defined name and description.
 func XappPciNumConflicts() *xappPciNumConflicts {
 	return &xappPciNumConflicts{
-		name:        xappPciNumConflictsKPIName,
-		description: xappPciNumConflictsDescription,
+		name:                  xappPciNumConflictsKPIName,
+		description:           xappPciNumConflictsDescription,
+		infoName:              xappPciCellInfoKPIName,
+		infoDescription:       xappPciCellInfoDescription,
+		pciName:               xappPciCellPciKPIName,
+		pciDescription:        xappPciCellPciDescription,
+		dlearfcnName:          xappPciCellDlearfcnKPIName,
+		dlearfcnDescription:   xappPciCellDlearfcnDescription,
+		neighborName:          xappPciNeighborKPIName,
+		neighborDescription:   xappPciNeighborDescription,
+		neighborsName:         xappPciNeighborsKPIName,
+		neighborsDescription:  xappPciNeighborsDescription,
+		asymmetricName:        xappPciAsymmetricKPIName,
+		asymmetricDescription: xappPciAsymmetricDescription,
+	}
+}
+
+// XappPciValidation defines the factory implementation of a kpi
+// xappPciValidation having a well defined name and description.
+func XappPciValidation() *xappPciValidation {
+	return &xappPciValidation{
+		name:                      xappPciCollisionsKPIName,
+		description:               xappPciCollisionsDescription,
+		confusionsName:            xappPciConfusionsKPIName,
+		confusionsDescription:     xappPciConfusionsDescription,
+		collisionCellsName:        xappPciCollisionCellsKPIName,
+		collisionCellsDescription: xappPciCollisionCellsDescription,
+		confusionCellsName:        xappPciConfusionCellsKPIName,
+		confusionCellsDescription: xappPciConfusionCellsDescription,
+	}
+}
+
+// XappPciHistory defines the factory implementation of a kpi
+// xappPciHistory having a well defined name and description.
+func XappPciHistory() *xappPciHistory {
+	return &xappPciHistory{
+		name:                   xappPciChangesKPIName,
+		description:            xappPciChangesDescription,
+		resolvedName:           xappPciResolvedTotalKPIName,
+		resolvedDescription:    xappPciResolvedTotalDescription,
+		previousPciName:        xappPciPreviousPciKPIName,
+		previousPciDescription: xappPciPreviousPciDescription,
+		lastChangeName:         xappPciLastChangeKPIName,
+		lastChangeDescription:  xappPciLastChangeDescription,
 	}
 }
 
@@ -93,6 +396,24 @@ func OnosTopoRelations() *topoRelations {
 	}
 }
 
+// OnosTopoAspects defines the factory implementation of a kpi
+// topoAspects having a well defined name and description.
+func OnosTopoAspects() *topoAspects {
+	return &topoAspects{
+		name:        topoAspectsKPIName,
+		description: topoAspectsKPIDescription,
+	}
+}
+
+// OnosTopoSummary defines the factory implementation of a kpi
+// topoSummary having a well defined name and description.
+func OnosTopoSummary() *topoSummary {
+	return &topoSummary{
+		name:        topoSummaryKPIName,
+		description: topoSummaryKPIDescription,
+	}
+}
+
 // OnosUenibUEs defines the factory implementation of a kpi
 // onosUenibUEs having a well defined name and description.
 func OnosUenibUEs() *onosUenibUEs {
@@ -101,3 +422,114 @@ func OnosUenibUEs() *onosUenibUEs {
 		description: OnosUenibUEsKPIDescription,
 	}
 }
+
+// OnosConfigModels defines the factory implementation of a kpi
+// onosConfigModels having a well defined name and description.
+func OnosConfigModels() *onosConfigModels {
+	return &onosConfigModels{
+		name:        onosConfigModelsKPIName,
+		description: onosConfigModelsKPIDescription,
+	}
+}
+
+// OnosConfigNetworkChanges defines the factory implementation of a kpi
+// onosConfigNetworkChanges having a well defined name and description.
+func OnosConfigNetworkChanges() *onosConfigNetworkChanges {
+	return &onosConfigNetworkChanges{
+		name:        onosConfigNetworkChangesKPIName,
+		description: onosConfigNetworkChangesKPIDescription,
+	}
+}
+
+// OnosConfigDeviceChanges defines the factory implementation of a kpi
+// onosConfigDeviceChanges having a well defined name and description.
+func OnosConfigDeviceChanges() *onosConfigDeviceChanges {
+	return &onosConfigDeviceChanges{
+		name:               onosConfigDeviceChangesKPIName,
+		description:        onosConfigDeviceChangesKPIDescription,
+		targetsName:        onosConfigTargetsKPIName,
+		targetsDescription: onosConfigTargetsKPIDescription,
+	}
+}
+
+// OnosRansimNodes defines the factory implementation of a kpi
+// onosRansimNodes having a well defined name and description.
+func OnosRansimNodes() *onosRansimNodes {
+	return &onosRansimNodes{
+		name:             onosRansimNodesKPIName,
+		description:      onosRansimNodesKPIDescription,
+		cellsName:        onosRansimNodeCellsKPIName,
+		cellsDescription: onosRansimNodeCellsKPIDescription,
+	}
+}
+
+// OnosRansimCells defines the factory implementation of a kpi
+// onosRansimCells having a well defined name and description.
+func OnosRansimCells() *onosRansimCells {
+	return &onosRansimCells{
+		name:                    onosRansimCellsKPIName,
+		description:             onosRansimCellsKPIDescription,
+		pciName:                 onosRansimCellPciKPIName,
+		pciDescription:          onosRansimCellPciKPIDescription,
+		earfcnName:              onosRansimCellEarfcnKPIName,
+		earfcnDescription:       onosRansimCellEarfcnKPIDescription,
+		maxUEsName:              onosRansimCellMaxUEsKPIName,
+		maxUEsDescription:       onosRansimCellMaxUEsKPIDescription,
+		uesName:                 onosRansimCellUEsKPIName,
+		uesDescription:          onosRansimCellUEsKPIDescription,
+		rrcConnectedName:        onosRansimCellRrcConnectedKPIName,
+		rrcConnectedDescription: onosRansimCellRrcConnectedKPIDescription,
+		rrcIdleName:             onosRansimCellRrcIdleKPIName,
+		rrcIdleDescription:      onosRansimCellRrcIdleKPIDescription,
+	}
+}
+
+// OnosRansimUEs defines the factory implementation of a kpi
+// onosRansimUEs having a well defined name and description.
+func OnosRansimUEs() *onosRansimUEs {
+	return &onosRansimUEs{
+		name:                    onosRansimUEsKPIName,
+		description:             onosRansimUEsKPIDescription,
+		servingRsrpName:         onosRansimUEServingRsrpKPIName,
+		servingRsrpDescription:  onosRansimUEServingRsrpKPIDescription,
+		neighborRsrpName:        onosRansimUENeighborRsrpKPIName,
+		neighborRsrpDescription: onosRansimUENeighborRsrpKPIDescription,
+		latitudeName:            onosRansimUELatitudeKPIName,
+		latitudeDescription:     onosRansimUELatitudeKPIDescription,
+		longitudeName:           onosRansimUELongitudeKPIName,
+		longitudeDescription:    onosRansimUELongitudeKPIDescription,
+	}
+}
+
+// OnosRansimRoutes defines the factory implementation of a kpi
+// onosRansimRoutes having a well defined name and description.
+func OnosRansimRoutes() *onosRansimRoutes {
+	return &onosRansimRoutes{
+		name:                 onosRansimRoutesKPIName,
+		description:          onosRansimRoutesKPIDescription,
+		waypointsName:        onosRansimRouteWaypointsKPIName,
+		waypointsDescription: onosRansimRouteWaypointsKPIDescription,
+	}
+}
+
+// Consistency defines the factory implementation of a kpi
+// consistency having a well defined name and description.
+func Consistency() *consistency {
+	return &consistency{
+		name:                  consistencyKPIName,
+		description:           consistencyKPIDescription,
+		mismatchesName:        consistencyMismatchesKPIName,
+		mismatchesDescription: consistencyMismatchesKPIDescription,
+	}
+}
+
+// GenericMetrics defines the factory implementation of a kpi
+// genericMetrics having a well defined name and description,
+// with metrics exported under the subsystem.
+func GenericMetrics(subsystem string) *genericMetrics {
+	return &genericMetrics{
+		name:        genericMetricsKPIName,
+		description: genericMetricsKPIDescription,
+		subsystem:   subsystem,
+	}
+}
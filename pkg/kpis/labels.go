@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/model"
+)
+
+// StaticLabel is the label of all the metrics of the KPIs of this
+// package, whose value identifies their source (e.g., e2t).
+const StaticLabel = "sdran"
+
+// ReservedLabels are the label names of the metrics of the KPIs of this
+// package, which can not be added to them by WithLabels. The labels
+// defined at runtime (e.g., expanded topo labels) are not listed, if one
+// of them clashes with an added label the label of the KPI is kept.
+var ReservedLabels = []string{
+	StaticLabel, "appid", "aspects", "cell_global_id", "cellid", "celltype", "channel_state",
+	"check", "connection_type", "connectivity_state", "device_type", "device_version",
+	"deviceid", "earfcn", "entityid", "id", "imsi", "kind", "labels", "module", "name",
+	"neighbor_cellid", "nodeid", "oid", "original_pci", "phase", "plmnid", "protocol",
+	"reference", "relationid", "remote_ip", "remote_port", "resolved_pci", "service_model",
+	"service_model_version", "service_state", "source", "state", "status", "target", "ueid",
+	"version",
+}
+
+// ValidateLabelName returns an error if name is not a valid prometheus
+// label name, or if it is one of the reserved names passed
+// (e.g., ReservedLabels).
+func ValidateLabelName(name string, reserved ...string) error {
+	if !model.LabelName(name).IsValid() || strings.HasPrefix(name, model.ReservedLabelPrefix) {
+		return fmt.Errorf("invalid label name %q", name)
+	}
+	for _, r := range reserved {
+		if name == r {
+			return fmt.Errorf("reserved label name %q", name)
+		}
+	}
+	return nil
+}
+
+// labeledKPI wraps a KPI, adding a set of labels
+// to each one of its metrics in the PrometheusFormat.
+type labeledKPI struct {
+	kpi    KPI
+	labels []*dto.LabelPair
+}
+
+// WithLabels returns a KPI that outputs the metrics of kpi
+// with the labels added to each one of them.
+func WithLabels(kpi KPI, labels map[string]string) KPI {
+	if len(labels) == 0 {
+		return kpi
+	}
+
+	pairs := []*dto.LabelPair{}
+	for name, value := range labels {
+		pairs = append(pairs, &dto.LabelPair{
+			Name:  proto.String(name),
+			Value: proto.String(value),
+		})
+	}
+
+	return &labeledKPI{
+		kpi:    kpi,
+		labels: pairs,
+	}
+}
+
+// Unwrap returns the KPI wrapped by labeledKPI.
+func (l *labeledKPI) Unwrap() KPI {
+	return l.kpi
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for labeledKPI.
+func (l *labeledKPI) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics, err := l.kpi.PrometheusFormat()
+
+	labeled := make([]prometheus.Metric, 0, len(metrics))
+	for _, metric := range metrics {
+		labeled = append(labeled, &labeledMetric{
+			Metric: metric,
+			labels: l.labels,
+		})
+	}
+
+	return labeled, err
+}
+
+// labeledMetric adds labels to the output of a prometheus.Metric.
+type labeledMetric struct {
+	prometheus.Metric
+	labels []*dto.LabelPair
+}
+
+func (m *labeledMetric) Write(out *dto.Metric) error {
+	if err := m.Metric.Write(out); err != nil {
+		return err
+	}
+
+	for _, label := range m.labels {
+		if !hasLabel(out.Label, label.GetName()) {
+			out.Label = append(out.Label, label)
+		}
+	}
+	sort.Slice(out.Label, func(i, j int) bool {
+		return out.Label[i].GetName() < out.Label[j].GetName()
+	})
+
+	return nil
+}
+
+// hasLabel returns true if labels contain one named name.
+func hasLabel(labels []*dto.LabelPair, name string) bool {
+	for _, label := range labels {
+		if label.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
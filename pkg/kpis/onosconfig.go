@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpis
+
+import (
+	"strings"
+
+	"github.com/onosproject/onos-lib-go/pkg/prom"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Var definitions of onos config metrics builder and static labels.
+// builder is used to create metrics in the PrometheusFormat.
+var (
+	staticLabelsConfig = map[string]string{"sdran": "config"}
+	onosConfigBuilder  = prom.NewBuilder("onos", "config", staticLabelsConfig)
+)
+
+// ConfigChangeStates defines the states of the network and device
+// changes of onos config, all of them are exported for each group
+// of changes, so absent states count 0.
+var ConfigChangeStates = []string{"pending", "complete", "failed"}
+
+type ConfigModel struct {
+	Name    string
+	Version string
+	Module  string
+}
+
+type ConfigNetworkChange struct {
+	ID    string
+	Phase string
+	State string
+}
+
+type ConfigDeviceChange struct {
+	ID            string
+	DeviceID      string
+	DeviceType    string
+	DeviceVersion string
+	Phase         string
+	State         string
+}
+
+// onosConfigModels defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Models stores each model plugin registered in onos config.
+type onosConfigModels struct {
+	name        string
+	description string
+	Labels      []string
+	LabelValues []string
+	Models      map[string]ConfigModel
+}
+
+// onosConfigNetworkChanges defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// NetworkChanges stores each network change (i.e., transaction),
+// which are counted per phase and state.
+type onosConfigNetworkChanges struct {
+	name           string
+	description    string
+	Labels         []string
+	LabelValues    []string
+	NetworkChanges map[string]ConfigNetworkChange
+}
+
+// onosConfigDeviceChanges defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// DeviceChanges stores each device change (i.e., configuration of a
+// target), which are counted per target, phase and state. The targets
+// of the changes are exported by an info metric too.
+type onosConfigDeviceChanges struct {
+	name               string
+	description        string
+	targetsName        string
+	targetsDescription string
+	Labels             []string
+	LabelValues        []string
+	DeviceChanges      map[string]ConfigDeviceChange
+}
+
+// configChangeCounts counts changes per group of label values and
+// state, the state being the last label of their metrics.
+type configChangeCounts map[string]map[string]float64
+
+func (c configChangeCounts) add(state string, groupValues ...string) {
+	group := strings.Join(groupValues, "\x00")
+	if _, ok := c[group]; !ok {
+		c[group] = make(map[string]float64)
+		for _, s := range ConfigChangeStates {
+			c[group][s] = 0
+		}
+	}
+	c[group][state]++
+}
+
+func (c configChangeCounts) metrics(metricDesc *prometheus.Desc) []prometheus.Metric {
+	metrics := []prometheus.Metric{}
+	for group, states := range c {
+		for state, count := range states {
+			labelValues := append(strings.Split(group, "\x00"), state)
+			metric := onosConfigBuilder.MustNewConstMetric(
+				metricDesc,
+				prometheus.GaugeValue,
+				count,
+				labelValues...,
+			)
+			metrics = append(metrics, metric)
+		}
+	}
+	return metrics
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for onosConfigModels.
+func (c *onosConfigModels) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	c.Labels = []string{"name", "version", "module"}
+	metricDesc := onosConfigBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsConfig)
+
+	for _, model := range c.Models {
+		metric := onosConfigBuilder.MustNewConstMetric(
+			metricDesc,
+			prometheus.GaugeValue,
+			1,
+			model.Name,
+			model.Version,
+			model.Module,
+		)
+		metrics = append(metrics, metric)
+	}
+
+	return metrics, nil
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for onosConfigNetworkChanges.
+func (c *onosConfigNetworkChanges) PrometheusFormat() ([]prometheus.Metric, error) {
+	c.Labels = []string{"phase", "state"}
+	metricDesc := onosConfigBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsConfig)
+
+	counts := configChangeCounts{}
+	for _, change := range c.NetworkChanges {
+		counts.add(change.State, change.Phase)
+	}
+
+	return counts.metrics(metricDesc), nil
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for onosConfigDeviceChanges.
+func (c *onosConfigDeviceChanges) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	c.Labels = []string{"deviceid", "device_type", "device_version", "phase", "state"}
+	metricDesc := onosConfigBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsConfig)
+	targetsDesc := onosConfigBuilder.NewMetricDesc(c.targetsName, c.targetsDescription, c.Labels[:3], staticLabelsConfig)
+
+	counts := configChangeCounts{}
+	targets := make(map[string][]string)
+	for _, change := range c.DeviceChanges {
+		counts.add(change.State, change.DeviceID, change.DeviceType, change.DeviceVersion, change.Phase)
+		target := []string{change.DeviceID, change.DeviceType, change.DeviceVersion}
+		targets[strings.Join(target, "\x00")] = target
+	}
+
+	metrics = append(metrics, counts.metrics(metricDesc)...)
+	for _, target := range targets {
+		metric := onosConfigBuilder.MustNewConstMetric(
+			targetsDesc,
+			prometheus.GaugeValue,
+			1,
+			target...,
+		)
+		metrics = append(metrics, metric)
+	}
+
+	return metrics, nil
+}
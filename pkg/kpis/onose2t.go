@@ -5,6 +5,9 @@
 package kpis
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/onosproject/onos-lib-go/pkg/prom"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -23,18 +26,32 @@ type E2tConnection struct {
 	RemoteIp       string
 	RemotePort     string
 	ConnectionType string
+	RanFunctions   []string
+	Age            float64
+	RemoteSubnets  []string
 }
 
 // onosE2tConnections defines the common data that can be used
 // to output the format of a KPI (e.g., PrometheusFormat).
 // NumberConnections stores each data structure for a connection
 // which contains the annotations as defined by E2tConnection struct.
+// The connections are also counted in total and by connection type,
+// plmnid and remote subnet, and their RAN functions define the number
+// of nodes advertising each RAN function OID.
 type onosE2tConnections struct {
-	name              string
-	description       string
-	Labels            []string
-	LabelValues       []string
-	NumberConnections map[string]E2tConnection
+	name                    string
+	description             string
+	countName               string
+	countDescription        string
+	countByName             string
+	countByDescription      string
+	ageName                 string
+	ageDescription          string
+	ranFunctionsName        string
+	ranFunctionsDescription string
+	Labels                  []string
+	LabelValues             []string
+	NumberConnections       map[string]E2tConnection
 }
 
 // PrometheusFormat implements the contract behavior of the kpis.KPI
@@ -44,6 +61,7 @@ func (c *onosE2tConnections) PrometheusFormat() ([]prometheus.Metric, error) {
 
 	c.Labels = []string{"id", "nodeid", "plmnid", "remote_ip", "remote_port", "connection_type"}
 	metricDesc := onose2tBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsE2t)
+	ageMetricDesc := onose2tBuilder.NewMetricDesc(c.ageName, c.ageDescription, []string{"id", "nodeid"}, staticLabelsE2t)
 
 	for _, e2tCon := range c.NumberConnections {
 		metric := onose2tBuilder.MustNewConstMetric(
@@ -57,8 +75,286 @@ func (c *onosE2tConnections) PrometheusFormat() ([]prometheus.Metric, error) {
 			e2tCon.RemotePort,
 			e2tCon.ConnectionType,
 		)
+		ageMetric := onose2tBuilder.MustNewConstMetric(
+			ageMetricDesc,
+			prometheus.GaugeValue,
+			e2tCon.Age,
+			e2tCon.Id,
+			e2tCon.NodeId,
+		)
+		metrics = append(metrics, metric, ageMetric)
+	}
+
+	metrics = append(metrics, c.countsFormat()...)
+	metrics = append(metrics, c.ranFunctionsFormat()...)
+
+	return metrics, nil
+}
+
+// countsFormat outputs the number of connections in total and
+// by connection type, plmnid and remote subnet in the PrometheusFormat.
+// A connection with multiple remote subnets counts once in each of them.
+func (c *onosE2tConnections) countsFormat() []prometheus.Metric {
+	countDesc := onose2tBuilder.NewMetricDesc(c.countName, c.countDescription, []string{}, staticLabelsE2t)
+	metrics := []prometheus.Metric{
+		onose2tBuilder.MustNewConstMetric(countDesc, prometheus.GaugeValue, float64(len(c.NumberConnections))),
+	}
+
+	byType := make(map[string]float64)
+	byPlmnID := make(map[string]float64)
+	bySubnet := make(map[string]float64)
+	for _, e2tCon := range c.NumberConnections {
+		byType[e2tCon.ConnectionType]++
+		byPlmnID[e2tCon.PlmnId]++
+		for _, subnet := range e2tCon.RemoteSubnets {
+			bySubnet[subnet]++
+		}
+	}
+
+	for _, by := range []struct {
+		name   string
+		label  string
+		counts map[string]float64
+	}{
+		{"type", "connection_type", byType},
+		{"plmnid", "plmnid", byPlmnID},
+		{"subnet", "remote_subnet", bySubnet},
+	} {
+		metricDesc := onose2tBuilder.NewMetricDesc(
+			fmt.Sprintf(c.countByName, by.name),
+			fmt.Sprintf(c.countByDescription, by.name),
+			[]string{by.label},
+			staticLabelsE2t,
+		)
+		for value, count := range by.counts {
+			metric := onose2tBuilder.MustNewConstMetric(metricDesc, prometheus.GaugeValue, count, value)
+			metrics = append(metrics, metric)
+		}
+	}
+
+	return metrics
+}
+
+// ranFunctionsFormat outputs the number of distinct nodes
+// advertising each RAN function OID in the PrometheusFormat.
+func (c *onosE2tConnections) ranFunctionsFormat() []prometheus.Metric {
+	metrics := []prometheus.Metric{}
+
+	nodes := make(map[string]map[string]bool)
+	for _, e2tCon := range c.NumberConnections {
+		for _, oid := range e2tCon.RanFunctions {
+			if _, ok := nodes[oid]; !ok {
+				nodes[oid] = make(map[string]bool)
+			}
+			nodes[oid][e2tCon.NodeId] = true
+		}
+	}
+
+	metricDesc := onose2tBuilder.NewMetricDesc(c.ranFunctionsName, c.ranFunctionsDescription, []string{"oid"}, staticLabelsE2t)
+	for oid, oidNodes := range nodes {
+		metric := onose2tBuilder.MustNewConstMetric(
+			metricDesc,
+			prometheus.GaugeValue,
+			float64(len(oidNodes)),
+			oid,
+		)
+		metrics = append(metrics, metric)
+	}
+
+	return metrics
+}
+
+type E2tNodeLifecycle struct {
+	NodeId      string
+	Connects    float64
+	Disconnects float64
+	Flapping    bool
+}
+
+// onosE2tNodeLifecycle defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Nodes stores the connects and disconnects of each e2 node seen by
+// the exporter, and whether it is flapping.
+type onosE2tNodeLifecycle struct {
+	name                   string
+	description            string
+	disconnectsName        string
+	disconnectsDescription string
+	flappingName           string
+	flappingDescription    string
+	Labels                 []string
+	LabelValues            []string
+	Nodes                  map[string]E2tNodeLifecycle
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for onosE2tNodeLifecycle.
+func (c *onosE2tNodeLifecycle) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	c.Labels = []string{"nodeid"}
+	connectsDesc := onose2tBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsE2t)
+	disconnectsDesc := onose2tBuilder.NewMetricDesc(c.disconnectsName, c.disconnectsDescription, c.Labels, staticLabelsE2t)
+	flappingDesc := onose2tBuilder.NewMetricDesc(c.flappingName, c.flappingDescription, c.Labels, staticLabelsE2t)
+
+	for _, node := range c.Nodes {
+		flapping := 0.0
+		if node.Flapping {
+			flapping = 1
+		}
+		metrics = append(metrics,
+			onose2tBuilder.MustNewConstMetric(connectsDesc, prometheus.CounterValue, node.Connects, node.NodeId),
+			onose2tBuilder.MustNewConstMetric(disconnectsDesc, prometheus.CounterValue, node.Disconnects, node.NodeId),
+			onose2tBuilder.MustNewConstMetric(flappingDesc, prometheus.GaugeValue, flapping, node.NodeId),
+		)
+	}
+
+	return metrics, nil
+}
+
+type E2tServiceModel struct {
+	Name    string
+	Version string
+}
+
+// onosE2tServiceModels defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// ServiceModels stores each service model plugin loaded by e2t.
+type onosE2tServiceModels struct {
+	name          string
+	description   string
+	Labels        []string
+	LabelValues   []string
+	ServiceModels map[string]E2tServiceModel
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for onosE2tServiceModels.
+func (c *onosE2tServiceModels) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	c.Labels = []string{"name", "version"}
+	metricDesc := onose2tBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsE2t)
+
+	for _, sm := range c.ServiceModels {
+		metric := onose2tBuilder.MustNewConstMetric(
+			metricDesc,
+			prometheus.GaugeValue,
+			1,
+			sm.Name,
+			sm.Version,
+		)
 		metrics = append(metrics, metric)
 	}
 
 	return metrics, nil
 }
+
+// E2LifecycleStates defines the states of the lifecycle of E2
+// subscriptions and channels, all of them are exported for each
+// group of subscriptions or channels, so absent states count 0.
+var E2LifecycleStates = []string{"pending", "complete", "failed"}
+
+type E2tSubscription struct {
+	ID                  string
+	NodeID              string
+	ServiceModel        string
+	ServiceModelVersion string
+	Phase               string
+	State               string
+}
+
+type E2tChannel struct {
+	ID                  string
+	AppID               string
+	NodeID              string
+	ServiceModel        string
+	ServiceModelVersion string
+	Phase               string
+	State               string
+}
+
+// onosE2tSubscriptions defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Subscriptions stores each E2 subscription, which are counted per
+// node, service model, phase and state.
+type onosE2tSubscriptions struct {
+	name          string
+	description   string
+	Labels        []string
+	LabelValues   []string
+	Subscriptions map[string]E2tSubscription
+}
+
+// onosE2tChannels defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Channels stores each E2 channel, which are counted per
+// xapp, node, service model, phase and state.
+type onosE2tChannels struct {
+	name        string
+	description string
+	Labels      []string
+	LabelValues []string
+	Channels    map[string]E2tChannel
+}
+
+// e2LifecycleCounts counts subscriptions or channels per group of
+// label values and lifecycle state, the state being the last label
+// of their metrics.
+type e2LifecycleCounts map[string]map[string]float64
+
+func (c e2LifecycleCounts) add(state string, groupValues ...string) {
+	group := strings.Join(groupValues, "\x00")
+	if _, ok := c[group]; !ok {
+		c[group] = make(map[string]float64)
+		for _, s := range E2LifecycleStates {
+			c[group][s] = 0
+		}
+	}
+	c[group][state]++
+}
+
+func (c e2LifecycleCounts) metrics(metricDesc *prometheus.Desc) []prometheus.Metric {
+	metrics := []prometheus.Metric{}
+	for group, states := range c {
+		for state, count := range states {
+			labelValues := append(strings.Split(group, "\x00"), state)
+			metric := onose2tBuilder.MustNewConstMetric(
+				metricDesc,
+				prometheus.GaugeValue,
+				count,
+				labelValues...,
+			)
+			metrics = append(metrics, metric)
+		}
+	}
+	return metrics
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for onosE2tSubscriptions.
+func (c *onosE2tSubscriptions) PrometheusFormat() ([]prometheus.Metric, error) {
+	c.Labels = []string{"nodeid", "service_model", "service_model_version", "phase", "state"}
+	metricDesc := onose2tBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsE2t)
+
+	counts := e2LifecycleCounts{}
+	for _, sub := range c.Subscriptions {
+		counts.add(sub.State, sub.NodeID, sub.ServiceModel, sub.ServiceModelVersion, sub.Phase)
+	}
+
+	return counts.metrics(metricDesc), nil
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for onosE2tChannels.
+func (c *onosE2tChannels) PrometheusFormat() ([]prometheus.Metric, error) {
+	c.Labels = []string{"appid", "nodeid", "service_model", "service_model_version", "phase", "state"}
+	metricDesc := onose2tBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsE2t)
+
+	counts := e2LifecycleCounts{}
+	for _, channel := range c.Channels {
+		counts.add(channel.State, channel.AppID, channel.NodeID, channel.ServiceModel, channel.ServiceModelVersion, channel.Phase)
+	}
+
+	return counts.metrics(metricDesc), nil
+}
@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpis
+
+import (
+	"github.com/onosproject/onos-lib-go/pkg/prom"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Var definitions of ransim metrics builder and static labels.
+// builder is used to create metrics in the PrometheusFormat.
+var (
+	staticLabelsRansim = map[string]string{"sdran": "ransim"}
+	ransimBuilder      = prom.NewBuilder("onos", "ransim", staticLabelsRansim)
+)
+
+type RansimNode struct {
+	NodeID string
+	Status string
+	Cells  float64
+}
+
+type RansimCell struct {
+	CellID            string
+	CellType          string
+	Pci               float64
+	Earfcn            float64
+	MaxUEs            float64
+	UEs               float64
+	RrcConnectedCount float64
+	RrcIdleCount      float64
+}
+
+// RansimTower defines the signal strength (RSRP) of a cell
+// measured by a simulated UE.
+type RansimTower struct {
+	CellID   string
+	Strength float64
+}
+
+type RansimUE struct {
+	IMSI      string
+	Serving   RansimTower
+	Neighbors []RansimTower
+	Latitude  float64
+	Longitude float64
+}
+
+type RansimRoute struct {
+	IMSI      string
+	Waypoints float64
+	NextPoint float64
+}
+
+// onosRansimNodes defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Nodes stores the simulated E2 nodes and their number of cells.
+type onosRansimNodes struct {
+	name             string
+	description      string
+	cellsName        string
+	cellsDescription string
+	Labels           []string
+	LabelValues      []string
+	Nodes            map[string]RansimNode
+}
+
+// onosRansimCells defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Cells stores the simulated cells, their PCI and EARFCN, and the
+// number of UEs they serve, by UE list and by RRC state.
+type onosRansimCells struct {
+	name                    string
+	description             string
+	pciName                 string
+	pciDescription          string
+	earfcnName              string
+	earfcnDescription       string
+	maxUEsName              string
+	maxUEsDescription       string
+	uesName                 string
+	uesDescription          string
+	rrcConnectedName        string
+	rrcConnectedDescription string
+	rrcIdleName             string
+	rrcIdleDescription      string
+	Labels                  []string
+	LabelValues             []string
+	Cells                   map[string]RansimCell
+}
+
+// onosRansimUEs defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// UEs stores the simulated UEs, their serving cell, the RSRP
+// of their serving and neighbor cells and their position.
+type onosRansimUEs struct {
+	name                    string
+	description             string
+	servingRsrpName         string
+	servingRsrpDescription  string
+	neighborRsrpName        string
+	neighborRsrpDescription string
+	latitudeName            string
+	latitudeDescription     string
+	longitudeName           string
+	longitudeDescription    string
+	Labels                  []string
+	LabelValues             []string
+	UEs                     map[string]RansimUE
+}
+
+// onosRansimRoutes defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Routes stores the routes of the simulated UEs, their progress
+// being the ratio of their waypoints already reached.
+type onosRansimRoutes struct {
+	name                 string
+	description          string
+	waypointsName        string
+	waypointsDescription string
+	Labels               []string
+	LabelValues          []string
+	Routes               map[string]RansimRoute
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for onosRansimNodes.
+func (c *onosRansimNodes) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	c.Labels = []string{"nodeid", "status"}
+	infoDesc := ransimBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsRansim)
+	cellsDesc := ransimBuilder.NewMetricDesc(c.cellsName, c.cellsDescription, []string{"nodeid"}, staticLabelsRansim)
+
+	for _, node := range c.Nodes {
+		metrics = append(metrics,
+			ransimBuilder.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1, node.NodeID, node.Status),
+			ransimBuilder.MustNewConstMetric(cellsDesc, prometheus.GaugeValue, node.Cells, node.NodeID),
+		)
+	}
+
+	return metrics, nil
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for onosRansimCells.
+func (c *onosRansimCells) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	c.Labels = []string{"cellid", "celltype"}
+	infoDesc := ransimBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsRansim)
+
+	cellLabels := []string{"cellid"}
+	pciDesc := ransimBuilder.NewMetricDesc(c.pciName, c.pciDescription, cellLabels, staticLabelsRansim)
+	earfcnDesc := ransimBuilder.NewMetricDesc(c.earfcnName, c.earfcnDescription, cellLabels, staticLabelsRansim)
+	maxUEsDesc := ransimBuilder.NewMetricDesc(c.maxUEsName, c.maxUEsDescription, cellLabels, staticLabelsRansim)
+	uesDesc := ransimBuilder.NewMetricDesc(c.uesName, c.uesDescription, cellLabels, staticLabelsRansim)
+	rrcConnectedDesc := ransimBuilder.NewMetricDesc(c.rrcConnectedName, c.rrcConnectedDescription, cellLabels, staticLabelsRansim)
+	rrcIdleDesc := ransimBuilder.NewMetricDesc(c.rrcIdleName, c.rrcIdleDescription, cellLabels, staticLabelsRansim)
+
+	for _, cell := range c.Cells {
+		metrics = append(metrics,
+			ransimBuilder.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1, cell.CellID, cell.CellType),
+			ransimBuilder.MustNewConstMetric(pciDesc, prometheus.GaugeValue, cell.Pci, cell.CellID),
+			ransimBuilder.MustNewConstMetric(earfcnDesc, prometheus.GaugeValue, cell.Earfcn, cell.CellID),
+			ransimBuilder.MustNewConstMetric(maxUEsDesc, prometheus.GaugeValue, cell.MaxUEs, cell.CellID),
+			ransimBuilder.MustNewConstMetric(uesDesc, prometheus.GaugeValue, cell.UEs, cell.CellID),
+			ransimBuilder.MustNewConstMetric(rrcConnectedDesc, prometheus.GaugeValue, cell.RrcConnectedCount, cell.CellID),
+			ransimBuilder.MustNewConstMetric(rrcIdleDesc, prometheus.GaugeValue, cell.RrcIdleCount, cell.CellID),
+		)
+	}
+
+	return metrics, nil
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for onosRansimUEs.
+func (c *onosRansimUEs) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	c.Labels = []string{"imsi", "cellid"}
+	servingDesc := ransimBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsRansim)
+	servingRsrpDesc := ransimBuilder.NewMetricDesc(c.servingRsrpName, c.servingRsrpDescription, c.Labels, staticLabelsRansim)
+	neighborRsrpDesc := ransimBuilder.NewMetricDesc(c.neighborRsrpName, c.neighborRsrpDescription, c.Labels, staticLabelsRansim)
+	latitudeDesc := ransimBuilder.NewMetricDesc(c.latitudeName, c.latitudeDescription, []string{"imsi"}, staticLabelsRansim)
+	longitudeDesc := ransimBuilder.NewMetricDesc(c.longitudeName, c.longitudeDescription, []string{"imsi"}, staticLabelsRansim)
+
+	for _, ue := range c.UEs {
+		metrics = append(metrics,
+			ransimBuilder.MustNewConstMetric(latitudeDesc, prometheus.GaugeValue, ue.Latitude, ue.IMSI),
+			ransimBuilder.MustNewConstMetric(longitudeDesc, prometheus.GaugeValue, ue.Longitude, ue.IMSI),
+		)
+		if ue.Serving.CellID != "" {
+			metrics = append(metrics,
+				ransimBuilder.MustNewConstMetric(servingDesc, prometheus.GaugeValue, 1, ue.IMSI, ue.Serving.CellID),
+				ransimBuilder.MustNewConstMetric(servingRsrpDesc, prometheus.GaugeValue, ue.Serving.Strength, ue.IMSI, ue.Serving.CellID),
+			)
+		}
+		for _, neighbor := range ue.Neighbors {
+			metric := ransimBuilder.MustNewConstMetric(
+				neighborRsrpDesc,
+				prometheus.GaugeValue,
+				neighbor.Strength,
+				ue.IMSI,
+				neighbor.CellID,
+			)
+			metrics = append(metrics, metric)
+		}
+	}
+
+	return metrics, nil
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for onosRansimRoutes.
+func (c *onosRansimRoutes) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	c.Labels = []string{"imsi"}
+	progressDesc := ransimBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsRansim)
+	waypointsDesc := ransimBuilder.NewMetricDesc(c.waypointsName, c.waypointsDescription, c.Labels, staticLabelsRansim)
+
+	for _, route := range c.Routes {
+		progress := 0.0
+		if route.Waypoints > 0 {
+			progress = route.NextPoint / route.Waypoints
+		}
+		metrics = append(metrics,
+			ransimBuilder.MustNewConstMetric(progressDesc, prometheus.GaugeValue, progress, route.IMSI),
+			ransimBuilder.MustNewConstMetric(waypointsDesc, prometheus.GaugeValue, route.Waypoints, route.IMSI),
+		)
+	}
+
+	return metrics, nil
+}
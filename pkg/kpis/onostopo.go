@@ -16,36 +16,48 @@ var (
 	onosTopoBuilder      = prom.NewBuilder("onos", "topo", staticLabelsOnosTopo)
 )
 
+// TopoRelation defines a relation, its ExpandedLabels being the
+// values of the topo labels exported as individual labels.
 type TopoRelation struct {
-	ID      string
-	Kind    string
-	Source  string
-	Target  string
-	Labels  string
-	Aspects string
+	ID             string
+	Kind           string
+	Source         string
+	Target         string
+	Labels         string
+	Aspects        string
+	ExpandedLabels []string
 }
 
+// TopoEntity defines an entity, its ExpandedLabels being the
+// values of the topo labels exported as individual labels.
 type TopoEntity struct {
-	ID      string
-	Kind    string
-	Labels  string
-	Aspects string
+	ID             string
+	Kind           string
+	Labels         string
+	Aspects        string
+	ExpandedLabels []string
 }
 
+// topoRelations ExpandedLabels defines the names of the labels
+// of the topo labels exported as individual labels.
 type topoRelations struct {
-	name        string
-	description string
-	Labels      []string
-	LabelValues []string
-	Relations   map[string]TopoRelation
+	name           string
+	description    string
+	Labels         []string
+	LabelValues    []string
+	ExpandedLabels []string
+	Relations      map[string]TopoRelation
 }
 
+// topoEntities ExpandedLabels defines the names of the labels
+// of the topo labels exported as individual labels.
 type topoEntities struct {
-	name        string
-	description string
-	Labels      []string
-	LabelValues []string
-	Entities    map[string]TopoEntity
+	name           string
+	description    string
+	Labels         []string
+	LabelValues    []string
+	ExpandedLabels []string
+	Entities       map[string]TopoEntity
 }
 
 // PrometheusFormat implements the contract behavior of the kpis.KPI
@@ -53,7 +65,7 @@ type topoEntities struct {
 func (t *topoRelations) PrometheusFormat() ([]prometheus.Metric, error) {
 	metrics := []prometheus.Metric{}
 
-	t.Labels = []string{"relationid", "kind", "source", "target", "labels", "aspects"}
+	t.Labels = append([]string{"relationid", "kind", "source", "target", "labels", "aspects"}, t.ExpandedLabels...)
 	metricDesc := onosTopoBuilder.NewMetricDesc(t.name, t.description, t.Labels, staticLabelsOnosTopo)
 
 	for _, relation := range t.Relations {
@@ -61,12 +73,14 @@ func (t *topoRelations) PrometheusFormat() ([]prometheus.Metric, error) {
 			metricDesc,
 			prometheus.GaugeValue,
 			1.0,
-			relation.ID,
-			relation.Kind,
-			relation.Source,
-			relation.Target,
-			relation.Labels,
-			relation.Aspects,
+			append([]string{
+				relation.ID,
+				relation.Kind,
+				relation.Source,
+				relation.Target,
+				relation.Labels,
+				relation.Aspects,
+			}, relation.ExpandedLabels...)...,
 		)
 		metrics = append(metrics, metric)
 	}
@@ -79,7 +93,7 @@ func (t *topoRelations) PrometheusFormat() ([]prometheus.Metric, error) {
 func (t *topoEntities) PrometheusFormat() ([]prometheus.Metric, error) {
 	metrics := []prometheus.Metric{}
 
-	t.Labels = []string{"entityid", "kind", "labels", "aspects"}
+	t.Labels = append([]string{"entityid", "kind", "labels", "aspects"}, t.ExpandedLabels...)
 	metricDesc := onosTopoBuilder.NewMetricDesc(t.name, t.description, t.Labels, staticLabelsOnosTopo)
 
 	for _, relation := range t.Entities {
@@ -87,13 +101,210 @@ func (t *topoEntities) PrometheusFormat() ([]prometheus.Metric, error) {
 			metricDesc,
 			prometheus.GaugeValue,
 			1.0,
-			relation.ID,
-			relation.Kind,
-			relation.Labels,
-			relation.Aspects,
+			append([]string{
+				relation.ID,
+				relation.Kind,
+				relation.Labels,
+				relation.Aspects,
+			}, relation.ExpandedLabels...)...,
 		)
 		metrics = append(metrics, metric)
 	}
 
 	return metrics, nil
 }
+
+type TopoServiceModel struct {
+	Name string
+	OID  string
+}
+
+type TopoE2Cell struct {
+	CellObjectID     string
+	CellGlobalID     string
+	CellGlobalIDType string
+	CellType         string
+	AntennaCount     uint32
+	EARFCN           uint32
+	PCI              uint32
+}
+
+type TopoLocation struct {
+	Lat float64
+	Lng float64
+}
+
+type TopoCoverage struct {
+	Height   int32
+	ArcWidth int32
+	Azimuth  int32
+	Tilt     int32
+}
+
+type TopoMastership struct {
+	Term   uint64
+	Master string
+}
+
+// TopoProtocolState defines the state of the connection of a
+// protocol (e.g., gNMI) to an entity (e.g., an onos config target).
+type TopoProtocolState struct {
+	Protocol          string
+	ConnectivityState string
+	ChannelState      string
+	ServiceState      string
+	Connected         bool
+}
+
+// TopoEntityAspects defines the aspects of an entity decoded
+// from their protobuf/JSON forms, nil if the entity does not
+// have them.
+type TopoEntityAspects struct {
+	ID            string
+	Kind          string
+	ServiceModels []TopoServiceModel
+	E2Cell        *TopoE2Cell
+	Location      *TopoLocation
+	Coverage      *TopoCoverage
+	Mastership    *TopoMastership
+	Protocols     []TopoProtocolState
+}
+
+// topoAspects defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Entities stores the decoded aspects of each entity, their fields
+// being exported as labels of info metrics or as numeric metrics.
+type topoAspects struct {
+	name        string
+	description string
+	Labels      []string
+	LabelValues []string
+	Entities    map[string]TopoEntityAspects
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for topoAspects.
+func (t *topoAspects) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	t.Labels = []string{"entityid", "kind"}
+	desc := func(name, description string, labels ...string) *prometheus.Desc {
+		return onosTopoBuilder.NewMetricDesc(t.name+"_"+name, description, append(append([]string{}, t.Labels...), labels...), staticLabelsOnosTopo)
+	}
+
+	serviceModelDesc := desc("e2node_service_model_info", "The service models supported by an e2 node", "name", "oid")
+	cellDesc := desc("e2cell_info", "The e2 cell identifiers and type", "cell_object_id", "cell_global_id", "cell_global_id_type", "cell_type")
+	pciDesc := desc("e2cell_pci", "The PCI of an e2 cell")
+	earfcnDesc := desc("e2cell_earfcn", "The EARFCN of an e2 cell")
+	antennaDesc := desc("e2cell_antenna_count", "The number of antennas of an e2 cell")
+	latDesc := desc("location_latitude", "The latitude of an entity location")
+	lngDesc := desc("location_longitude", "The longitude of an entity location")
+	heightDesc := desc("coverage_height", "The height of an entity coverage")
+	arcWidthDesc := desc("coverage_arc_width", "The arc width of an entity coverage in degrees")
+	azimuthDesc := desc("coverage_azimuth", "The azimuth of an entity coverage in degrees")
+	tiltDesc := desc("coverage_tilt", "The tilt of an entity coverage in degrees")
+	masterDesc := desc("mastership_info", "The master of an entity", "master")
+	termDesc := desc("mastership_term", "The mastership term of an entity")
+	protocolDesc := desc("protocol_info", "The connectivity, channel and service states of a protocol of an entity", "protocol", "connectivity_state", "channel_state", "service_state")
+	connectedDesc := desc("protocol_connected", "Whether the channel of a protocol of an entity is connected", "protocol")
+
+	gauge := func(metricDesc *prometheus.Desc, value float64, labelValues ...string) {
+		metric := onosTopoBuilder.MustNewConstMetric(metricDesc, prometheus.GaugeValue, value, labelValues...)
+		metrics = append(metrics, metric)
+	}
+
+	for _, entity := range t.Entities {
+		for _, sm := range entity.ServiceModels {
+			gauge(serviceModelDesc, 1, entity.ID, entity.Kind, sm.Name, sm.OID)
+		}
+
+		if cell := entity.E2Cell; cell != nil {
+			gauge(cellDesc, 1, entity.ID, entity.Kind, cell.CellObjectID, cell.CellGlobalID, cell.CellGlobalIDType, cell.CellType)
+			gauge(pciDesc, float64(cell.PCI), entity.ID, entity.Kind)
+			gauge(earfcnDesc, float64(cell.EARFCN), entity.ID, entity.Kind)
+			gauge(antennaDesc, float64(cell.AntennaCount), entity.ID, entity.Kind)
+		}
+
+		if location := entity.Location; location != nil {
+			gauge(latDesc, location.Lat, entity.ID, entity.Kind)
+			gauge(lngDesc, location.Lng, entity.ID, entity.Kind)
+		}
+
+		if coverage := entity.Coverage; coverage != nil {
+			gauge(heightDesc, float64(coverage.Height), entity.ID, entity.Kind)
+			gauge(arcWidthDesc, float64(coverage.ArcWidth), entity.ID, entity.Kind)
+			gauge(azimuthDesc, float64(coverage.Azimuth), entity.ID, entity.Kind)
+			gauge(tiltDesc, float64(coverage.Tilt), entity.ID, entity.Kind)
+		}
+
+		if mastership := entity.Mastership; mastership != nil {
+			gauge(masterDesc, 1, entity.ID, entity.Kind, mastership.Master)
+			gauge(termDesc, float64(mastership.Term), entity.ID, entity.Kind)
+		}
+
+		for _, protocol := range entity.Protocols {
+			gauge(protocolDesc, 1, entity.ID, entity.Kind, protocol.Protocol, protocol.ConnectivityState, protocol.ChannelState, protocol.ServiceState)
+			connected := 0.0
+			if protocol.Connected {
+				connected = 1
+			}
+			gauge(connectedDesc, connected, entity.ID, entity.Kind, protocol.Protocol)
+		}
+	}
+
+	return metrics, nil
+}
+
+// TopoSummary defines the counts summarizing a topology.
+// Entities and Relations count the objects per kind, OrphanedRelations
+// counts per kind the relations whose source or target entity no longer
+// exists, NodeCells counts the cells per e2 node and E2tNodes counts
+// the e2 nodes per e2t instance. CellIDs maps the identifiers of the
+// e2 cells (entity ID, cell object ID and cell global ID) to their
+// entity IDs, it is not exported but checked against other sources.
+type TopoSummary struct {
+	Entities          map[string]float64
+	Relations         map[string]float64
+	OrphanedRelations map[string]float64
+	NodeCells         map[string]float64
+	E2tNodes          map[string]float64
+	CellIDs           map[string]string
+}
+
+// topoSummary defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Summary stores the counts summarizing the topology.
+type topoSummary struct {
+	name        string
+	description string
+	Labels      []string
+	LabelValues []string
+	Summary     TopoSummary
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for topoSummary.
+func (t *topoSummary) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	for _, count := range []struct {
+		name        string
+		description string
+		label       string
+		values      map[string]float64
+	}{
+		{"entities_count", "The number of onos topo entities per kind", "kind", t.Summary.Entities},
+		{"relations_count", "The number of onos topo relations per kind", "kind", t.Summary.Relations},
+		{"relations_orphaned", "The number of onos topo relations per kind whose source or target entity does not exist", "kind", t.Summary.OrphanedRelations},
+		{"e2node_cells", "The number of cells contained by an e2 node", "entityid", t.Summary.NodeCells},
+		{"e2t_e2nodes", "The number of e2 nodes controlled by an e2t instance", "entityid", t.Summary.E2tNodes},
+	} {
+		metricDesc := onosTopoBuilder.NewMetricDesc(count.name, count.description, []string{count.label}, staticLabelsOnosTopo)
+		for value, n := range count.values {
+			metric := onosTopoBuilder.MustNewConstMetric(metricDesc, prometheus.GaugeValue, n, value)
+			metrics = append(metrics, metric)
+		}
+	}
+
+	return metrics, nil
+}
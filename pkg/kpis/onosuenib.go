@@ -21,14 +21,18 @@ type UE struct {
 	Aspects       []string
 	AspectsValues []string
 	Relations     map[string]TopoRelation
+	ServingCell   string
 }
 
+// onosUenibUEs defines the UEs of uenib, ServingCells being set
+// if the serving cell of the UEs was retrieved.
 type onosUenibUEs struct {
-	name        string
-	description string
-	Labels      []string
-	LabelValues []string
-	UEs         map[string]UE
+	name         string
+	description  string
+	Labels       []string
+	LabelValues  []string
+	UEs          map[string]UE
+	ServingCells bool
 }
 
 // PrometheusFormat implements the contract behavior of the kpis.KPI
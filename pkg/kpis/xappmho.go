@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpis
+
+import (
+	"github.com/onosproject/onos-lib-go/pkg/prom"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Var definitions of xapp mho metrics builder and static labels.
+// builder is used to create metrics in the PrometheusFormat.
+var (
+	staticLabelsXappMho = map[string]string{"sdran": "xappmho"}
+	xappMhoBuilder      = prom.NewBuilder("onos", "xappmho", staticLabelsXappMho)
+)
+
+type MhoParams struct {
+	A3Offset      float64
+	Hysteresis    float64
+	TimeToTrigger float64
+}
+
+// xappMhoParams defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Params stores the A3 event handover parameters in use by the xapp.
+type xappMhoParams struct {
+	name                     string
+	description              string
+	hysteresisName           string
+	hysteresisDescription    string
+	timeToTriggerName        string
+	timeToTriggerDescription string
+	Labels                   []string
+	LabelValues              []string
+	Params                   MhoParams
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for xappMhoParams.
+func (c *xappMhoParams) PrometheusFormat() ([]prometheus.Metric, error) {
+	c.Labels = []string{}
+
+	a3OffsetDesc := xappMhoBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsXappMho)
+	hysteresisDesc := xappMhoBuilder.NewMetricDesc(c.hysteresisName, c.hysteresisDescription, c.Labels, staticLabelsXappMho)
+	timeToTriggerDesc := xappMhoBuilder.NewMetricDesc(c.timeToTriggerName, c.timeToTriggerDescription, c.Labels, staticLabelsXappMho)
+
+	metrics := []prometheus.Metric{
+		xappMhoBuilder.MustNewConstMetric(a3OffsetDesc, prometheus.GaugeValue, c.Params.A3Offset),
+		xappMhoBuilder.MustNewConstMetric(hysteresisDesc, prometheus.GaugeValue, c.Params.Hysteresis),
+		xappMhoBuilder.MustNewConstMetric(timeToTriggerDesc, prometheus.GaugeValue, c.Params.TimeToTrigger),
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpis
+
+import (
+	"github.com/onosproject/onos-lib-go/pkg/prom"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Var definitions of xapp mlb metrics builder and static labels.
+// builder is used to create metrics in the PrometheusFormat.
+var (
+	staticLabelsXappMlb = map[string]string{"sdran": "xappmlb"}
+	xappMlbBuilder      = prom.NewBuilder("onos", "xappmlb", staticLabelsXappMlb)
+)
+
+type MlbParams struct {
+	Interval          float64
+	OverloadThreshold float64
+	TargetThreshold   float64
+	DeltaOcn          float64
+}
+
+type CellOcn struct {
+	CellID         string
+	NeighborCellID string
+	Ocn            float64
+}
+
+// xappMlbParams defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Params stores the load balancing parameters in use by the xapp.
+type xappMlbParams struct {
+	name                         string
+	description                  string
+	overloadThresholdName        string
+	overloadThresholdDescription string
+	targetThresholdName          string
+	targetThresholdDescription   string
+	deltaOcnName                 string
+	deltaOcnDescription          string
+	Labels                       []string
+	LabelValues                  []string
+	Params                       MlbParams
+}
+
+// xappMlbOcn defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Ocns stores the cell individual offsets set by the xapp,
+// one per cell and neighbor cell, and defines the number of
+// neighbors with an offset per cell.
+type xappMlbOcn struct {
+	name                 string
+	description          string
+	neighborsName        string
+	neighborsDescription string
+	Labels               []string
+	LabelValues          []string
+	Ocns                 []CellOcn
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for xappMlbParams.
+func (c *xappMlbParams) PrometheusFormat() ([]prometheus.Metric, error) {
+	c.Labels = []string{}
+
+	intervalDesc := xappMlbBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsXappMlb)
+	overloadThresholdDesc := xappMlbBuilder.NewMetricDesc(c.overloadThresholdName, c.overloadThresholdDescription, c.Labels, staticLabelsXappMlb)
+	targetThresholdDesc := xappMlbBuilder.NewMetricDesc(c.targetThresholdName, c.targetThresholdDescription, c.Labels, staticLabelsXappMlb)
+	deltaOcnDesc := xappMlbBuilder.NewMetricDesc(c.deltaOcnName, c.deltaOcnDescription, c.Labels, staticLabelsXappMlb)
+
+	metrics := []prometheus.Metric{
+		xappMlbBuilder.MustNewConstMetric(intervalDesc, prometheus.GaugeValue, c.Params.Interval),
+		xappMlbBuilder.MustNewConstMetric(overloadThresholdDesc, prometheus.GaugeValue, c.Params.OverloadThreshold),
+		xappMlbBuilder.MustNewConstMetric(targetThresholdDesc, prometheus.GaugeValue, c.Params.TargetThreshold),
+		xappMlbBuilder.MustNewConstMetric(deltaOcnDesc, prometheus.GaugeValue, c.Params.DeltaOcn),
+	}
+
+	return metrics, nil
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for xappMlbOcn.
+func (c *xappMlbOcn) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	c.Labels = []string{"cellid", "neighbor_cellid"}
+	metricDesc := xappMlbBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsXappMlb)
+	neighborsDesc := xappMlbBuilder.NewMetricDesc(c.neighborsName, c.neighborsDescription, []string{"cellid"}, staticLabelsXappMlb)
+
+	neighbors := make(map[string]int)
+	for _, ocn := range c.Ocns {
+		metric := xappMlbBuilder.MustNewConstMetric(
+			metricDesc,
+			prometheus.GaugeValue,
+			ocn.Ocn,
+			ocn.CellID,
+			ocn.NeighborCellID,
+		)
+		metrics = append(metrics, metric)
+		neighbors[ocn.CellID]++
+	}
+
+	for cellID, count := range neighbors {
+		metric := xappMlbBuilder.MustNewConstMetric(
+			neighborsDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			cellID,
+		)
+		metrics = append(metrics, metric)
+	}
+
+	return metrics, nil
+}
@@ -5,6 +5,8 @@
 package kpis
 
 import (
+	"fmt"
+
 	"github.com/onosproject/onos-lib-go/pkg/prom"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -28,19 +30,39 @@ type CellInfo struct {
 	NodeID        string
 	CellType      string
 	CellPci       string
+	Pci           uint32
 	CellDlearfcn  float64
 	CellNeighbors string
+	NeighborIDs   []string
 }
 
 // xapppciNumConflicts defines the common data that can be used
 // to output the format of a KPI (e.g., PrometheusFormat).
-// CellInfo stores the cell info.
+// CellInfo stores the cell info, exported by an info metric with the
+// identity labels of the cells and by gauges of their PCI and EARFCN.
+// Legacy enables the former info metric, valued by the EARFCN of the
+// cells and labeled by their PCI and neighbors.
+// The neighbors of the cells define their neighbor relations,
+// the number of neighbors per cell and the asymmetric relations.
 type xappPciNumConflicts struct {
-	name        string
-	description string
-	Labels      []string
-	LabelValues []string
-	Cells       map[string]CellInfo
+	name                  string
+	description           string
+	infoName              string
+	infoDescription       string
+	pciName               string
+	pciDescription        string
+	dlearfcnName          string
+	dlearfcnDescription   string
+	neighborName          string
+	neighborDescription   string
+	neighborsName         string
+	neighborsDescription  string
+	asymmetricName        string
+	asymmetricDescription string
+	Labels                []string
+	LabelValues           []string
+	Cells                 map[string]CellInfo
+	Legacy                bool
 }
 
 // xappPciResolvedConflicts defines the common data that can be used
@@ -59,8 +81,35 @@ type xappPciResolvedConflicts struct {
 func (c *xappPciNumConflicts) PrometheusFormat() ([]prometheus.Metric, error) {
 	metrics := []prometheus.Metric{}
 
-	c.Labels = []string{"cellid", "celltype", "nodeid", "pci", "neighbors"}
-	metricDesc := xappPciBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsXappPci)
+	c.Labels = []string{"cellid", "celltype", "nodeid"}
+	infoDesc := xappPciBuilder.NewMetricDesc(c.infoName, c.infoDescription, c.Labels, staticLabelsXappPci)
+	pciDesc := xappPciBuilder.NewMetricDesc(c.pciName, c.pciDescription, []string{"cellid"}, staticLabelsXappPci)
+	dlearfcnDesc := xappPciBuilder.NewMetricDesc(c.dlearfcnName, c.dlearfcnDescription, []string{"cellid"}, staticLabelsXappPci)
+
+	for _, cell := range c.Cells {
+		metrics = append(metrics,
+			xappPciBuilder.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1, cell.CellID, cell.CellType, cell.NodeID),
+			xappPciBuilder.MustNewConstMetric(pciDesc, prometheus.GaugeValue, float64(cell.Pci), cell.CellID),
+			xappPciBuilder.MustNewConstMetric(dlearfcnDesc, prometheus.GaugeValue, cell.CellDlearfcn, cell.CellID),
+		)
+	}
+
+	if c.Legacy {
+		metrics = append(metrics, c.legacyFormat()...)
+	}
+
+	metrics = append(metrics, c.neighborsFormat()...)
+
+	return metrics, nil
+}
+
+// legacyFormat outputs the former info metric of the cells,
+// valued by their EARFCN, in the PrometheusFormat.
+func (c *xappPciNumConflicts) legacyFormat() []prometheus.Metric {
+	metrics := []prometheus.Metric{}
+
+	labels := []string{"cellid", "celltype", "nodeid", "pci", "neighbors"}
+	metricDesc := xappPciBuilder.NewMetricDesc(c.name, c.description, labels, staticLabelsXappPci)
 
 	for _, cell := range c.Cells {
 		metric := xappPciBuilder.MustNewConstMetric(
@@ -76,7 +125,51 @@ func (c *xappPciNumConflicts) PrometheusFormat() ([]prometheus.Metric, error) {
 		metrics = append(metrics, metric)
 	}
 
-	return metrics, nil
+	return metrics
+}
+
+// neighborsFormat outputs a series per neighbor relation (cell,
+// neighbor), the number of neighbors of each cell and whether each
+// neighbor relation is asymmetric, i.e., the neighbor does not list
+// the cell as its neighbor (or it is not a known cell), in the
+// PrometheusFormat.
+func (c *xappPciNumConflicts) neighborsFormat() []prometheus.Metric {
+	metrics := []prometheus.Metric{}
+
+	pairLabels := []string{"cellid", "neighbor_cellid"}
+	neighborDesc := xappPciBuilder.NewMetricDesc(c.neighborName, c.neighborDescription, pairLabels, staticLabelsXappPci)
+	asymmetricDesc := xappPciBuilder.NewMetricDesc(c.asymmetricName, c.asymmetricDescription, pairLabels, staticLabelsXappPci)
+	neighborsDesc := xappPciBuilder.NewMetricDesc(c.neighborsName, c.neighborsDescription, []string{"cellid"}, staticLabelsXappPci)
+
+	for _, cell := range c.Cells {
+		neighbors := uniqueNeighbors(cell)
+		for _, neighborID := range neighbors {
+
+			asymmetric := 1.0
+			if neighbor, ok := c.Cells[neighborID]; ok && hasNeighbor(neighbor, cell.CellID) {
+				asymmetric = 0
+			}
+
+			metrics = append(metrics,
+				xappPciBuilder.MustNewConstMetric(neighborDesc, prometheus.GaugeValue, 1, cell.CellID, neighborID),
+				xappPciBuilder.MustNewConstMetric(asymmetricDesc, prometheus.GaugeValue, asymmetric, cell.CellID, neighborID),
+			)
+		}
+
+		metric := xappPciBuilder.MustNewConstMetric(neighborsDesc, prometheus.GaugeValue, float64(len(neighbors)), cell.CellID)
+		metrics = append(metrics, metric)
+	}
+
+	return metrics
+}
+
+func hasNeighbor(cell CellInfo, neighborID string) bool {
+	for _, id := range cell.NeighborIDs {
+		if id == neighborID {
+			return true
+		}
+	}
+	return false
 }
 
 // PrometheusFormat implements the contract behavior of the kpis.KPI
@@ -101,3 +194,162 @@ func (c *xappPciResolvedConflicts) PrometheusFormat() ([]prometheus.Metric, erro
 
 	return metrics, nil
 }
+
+// xappPciValidation defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Cells stores the cell info used to check the current PCI
+// assignment, independently of the conflicts reported by the xapp.
+type xappPciValidation struct {
+	name                      string
+	description               string
+	confusionsName            string
+	confusionsDescription     string
+	collisionCellsName        string
+	collisionCellsDescription string
+	confusionCellsName        string
+	confusionCellsDescription string
+	Labels                    []string
+	LabelValues               []string
+	Cells                     map[string]CellInfo
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for xappPciValidation.
+// A cell collides with each neighbor having its PCI on its EARFCN, and
+// it is confused by each neighbor sharing PCI and EARFCN with another
+// one of its neighbors, counted per EARFCN of the neighbors. Unknown
+// neighbors are ignored. The cells with collisions or confusions are
+// also counted per EARFCN.
+func (c *xappPciValidation) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	c.Labels = []string{"cellid", "earfcn"}
+	collisionsDesc := xappPciBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsXappPci)
+	confusionsDesc := xappPciBuilder.NewMetricDesc(c.confusionsName, c.confusionsDescription, c.Labels, staticLabelsXappPci)
+	collisionCellsDesc := xappPciBuilder.NewMetricDesc(c.collisionCellsName, c.collisionCellsDescription, []string{"earfcn"}, staticLabelsXappPci)
+	confusionCellsDesc := xappPciBuilder.NewMetricDesc(c.confusionCellsName, c.confusionCellsDescription, []string{"earfcn"}, staticLabelsXappPci)
+
+	collisionCells := make(map[string]float64)
+	confusionCells := make(map[string]float64)
+
+	for _, cell := range c.Cells {
+		earfcn := fmt.Sprintf("%d", uint32(cell.CellDlearfcn))
+		collisionCells[earfcn] += 0
+		confusionCells[earfcn] += 0
+
+		// confusions are counted per EARFCN of the neighbors,
+		// grouping them by EARFCN and PCI.
+		collisions := 0.0
+		confusions := map[string]float64{earfcn: 0}
+		groups := make(map[string]map[uint32]float64)
+		for _, neighborID := range uniqueNeighbors(cell) {
+			neighbor, ok := c.Cells[neighborID]
+			if !ok {
+				continue
+			}
+			neighborEarfcn := fmt.Sprintf("%d", uint32(neighbor.CellDlearfcn))
+			if neighborEarfcn == earfcn && neighbor.Pci == cell.Pci {
+				collisions++
+			}
+			if _, ok := groups[neighborEarfcn]; !ok {
+				groups[neighborEarfcn] = make(map[uint32]float64)
+			}
+			groups[neighborEarfcn][neighbor.Pci]++
+		}
+		for neighborEarfcn, pcis := range groups {
+			confusions[neighborEarfcn] += 0
+			for _, count := range pcis {
+				if count > 1 {
+					confusions[neighborEarfcn] += count
+				}
+			}
+		}
+
+		metrics = append(metrics, xappPciBuilder.MustNewConstMetric(collisionsDesc, prometheus.GaugeValue, collisions, cell.CellID, earfcn))
+		if collisions > 0 {
+			collisionCells[earfcn]++
+		}
+
+		for neighborEarfcn, count := range confusions {
+			metrics = append(metrics, xappPciBuilder.MustNewConstMetric(confusionsDesc, prometheus.GaugeValue, count, cell.CellID, neighborEarfcn))
+			confusionCells[neighborEarfcn] += 0
+			if count > 0 {
+				confusionCells[neighborEarfcn]++
+			}
+		}
+	}
+
+	for earfcn, count := range collisionCells {
+		metrics = append(metrics, xappPciBuilder.MustNewConstMetric(collisionCellsDesc, prometheus.GaugeValue, count, earfcn))
+	}
+	for earfcn, count := range confusionCells {
+		metrics = append(metrics, xappPciBuilder.MustNewConstMetric(confusionCellsDesc, prometheus.GaugeValue, count, earfcn))
+	}
+
+	return metrics, nil
+}
+
+// uniqueNeighbors returns the neighbors of a cell without duplicates.
+func uniqueNeighbors(cell CellInfo) []string {
+	neighbors := []string{}
+	seen := make(map[string]bool)
+	for _, neighborID := range cell.NeighborIDs {
+		if !seen[neighborID] {
+			seen[neighborID] = true
+			neighbors = append(neighbors, neighborID)
+		}
+	}
+	return neighbors
+}
+
+// CellHistory defines the PCI changes and resolved conflicts of a cell
+// seen by the exporter. PreviousPci and LastChange (in seconds since
+// epoch) are 0 while the PCI of the cell has not changed.
+type CellHistory struct {
+	CellID            string
+	PciChanges        float64
+	ResolvedConflicts float64
+	PreviousPci       float64
+	LastChange        float64
+}
+
+// xappPciHistory defines the common data that can be used
+// to output the format of a KPI (e.g., PrometheusFormat).
+// Cells stores the history of each cell, exported only labeled
+// by cell id so PCI changes do not create new series.
+type xappPciHistory struct {
+	name                   string
+	description            string
+	resolvedName           string
+	resolvedDescription    string
+	previousPciName        string
+	previousPciDescription string
+	lastChangeName         string
+	lastChangeDescription  string
+	Labels                 []string
+	LabelValues            []string
+	Cells                  map[string]CellHistory
+}
+
+// PrometheusFormat implements the contract behavior of the kpis.KPI
+// interface for xappPciHistory.
+func (c *xappPciHistory) PrometheusFormat() ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	c.Labels = []string{"cellid"}
+	changesDesc := xappPciBuilder.NewMetricDesc(c.name, c.description, c.Labels, staticLabelsXappPci)
+	resolvedDesc := xappPciBuilder.NewMetricDesc(c.resolvedName, c.resolvedDescription, c.Labels, staticLabelsXappPci)
+	previousPciDesc := xappPciBuilder.NewMetricDesc(c.previousPciName, c.previousPciDescription, c.Labels, staticLabelsXappPci)
+	lastChangeDesc := xappPciBuilder.NewMetricDesc(c.lastChangeName, c.lastChangeDescription, c.Labels, staticLabelsXappPci)
+
+	for _, cell := range c.Cells {
+		metrics = append(metrics,
+			xappPciBuilder.MustNewConstMetric(changesDesc, prometheus.CounterValue, cell.PciChanges, cell.CellID),
+			xappPciBuilder.MustNewConstMetric(resolvedDesc, prometheus.CounterValue, cell.ResolvedConflicts, cell.CellID),
+			xappPciBuilder.MustNewConstMetric(previousPciDesc, prometheus.GaugeValue, cell.PreviousPci, cell.CellID),
+			xappPciBuilder.MustNewConstMetric(lastChangeDesc, prometheus.GaugeValue, cell.LastChange, cell.CellID),
+		)
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpis
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+var fqNameRegexp = regexp.MustCompile(`fqName: "([^"]+)"`)
+
+// metricValues returns the values of the metrics of a KPI keyed by
+// their name and labels, except StaticLabel, in the form
+// name{label="value",...}.
+func metricValues(t *testing.T, kpi KPI) map[string]float64 {
+	metrics, err := kpi.PrometheusFormat()
+	assert.NoError(t, err)
+
+	values := make(map[string]float64)
+	for _, metric := range metrics {
+		m := &dto.Metric{}
+		assert.NoError(t, metric.Write(m))
+
+		labels := []string{}
+		for _, label := range m.Label {
+			if label.GetName() != StaticLabel {
+				labels = append(labels, fmt.Sprintf("%s=%q", label.GetName(), label.GetValue()))
+			}
+		}
+		sort.Strings(labels)
+
+		name := fqNameRegexp.FindStringSubmatch(metric.Desc().String())[1]
+		key := name + "{" + strings.Join(labels, ",") + "}"
+		switch {
+		case m.Gauge != nil:
+			values[key] = m.Gauge.GetValue()
+		case m.Counter != nil:
+			values[key] = m.Counter.GetValue()
+		case m.Untyped != nil:
+			values[key] = m.Untyped.GetValue()
+		}
+	}
+	return values
+}
+
+func pciCell(cellID string, pci uint32, earfcn float64, neighborIDs ...string) CellInfo {
+	return CellInfo{CellID: cellID, Pci: pci, CellDlearfcn: earfcn, NeighborIDs: neighborIDs}
+}
+
+func TestXappPciValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		cells    []CellInfo
+		expected map[string]float64
+	}{
+		{
+			name:  "no neighbors",
+			cells: []CellInfo{pciCell("a", 1, 100)},
+			expected: map[string]float64{
+				`onos_xapppci_cell_collisions{cellid="a",earfcn="100"}`: 0,
+				`onos_xapppci_cell_confusions{cellid="a",earfcn="100"}`: 0,
+				`onos_xapppci_collision_cells{earfcn="100"}`:            0,
+				`onos_xapppci_confusion_cells{earfcn="100"}`:            0,
+			},
+		},
+		{
+			name: "collision",
+			cells: []CellInfo{
+				pciCell("a", 1, 100, "b"),
+				pciCell("b", 1, 100, "a"),
+			},
+			expected: map[string]float64{
+				`onos_xapppci_cell_collisions{cellid="a",earfcn="100"}`: 1,
+				`onos_xapppci_cell_collisions{cellid="b",earfcn="100"}`: 1,
+				`onos_xapppci_cell_confusions{cellid="a",earfcn="100"}`: 0,
+				`onos_xapppci_cell_confusions{cellid="b",earfcn="100"}`: 0,
+				`onos_xapppci_collision_cells{earfcn="100"}`:            2,
+				`onos_xapppci_confusion_cells{earfcn="100"}`:            0,
+			},
+		},
+		{
+			name: "same pci on another earfcn",
+			cells: []CellInfo{
+				pciCell("a", 1, 100, "b"),
+				pciCell("b", 1, 200),
+			},
+			expected: map[string]float64{
+				`onos_xapppci_cell_collisions{cellid="a",earfcn="100"}`: 0,
+				`onos_xapppci_cell_collisions{cellid="b",earfcn="200"}`: 0,
+				`onos_xapppci_cell_confusions{cellid="a",earfcn="100"}`: 0,
+				`onos_xapppci_cell_confusions{cellid="a",earfcn="200"}`: 0,
+				`onos_xapppci_cell_confusions{cellid="b",earfcn="200"}`: 0,
+				`onos_xapppci_collision_cells{earfcn="100"}`:            0,
+				`onos_xapppci_collision_cells{earfcn="200"}`:            0,
+				`onos_xapppci_confusion_cells{earfcn="100"}`:            0,
+				`onos_xapppci_confusion_cells{earfcn="200"}`:            0,
+			},
+		},
+		{
+			name: "confusion",
+			cells: []CellInfo{
+				pciCell("a", 1, 100, "b", "c", "d"),
+				pciCell("b", 5, 200),
+				pciCell("c", 5, 200),
+				pciCell("d", 5, 100),
+			},
+			expected: map[string]float64{
+				`onos_xapppci_cell_collisions{cellid="a",earfcn="100"}`: 0,
+				`onos_xapppci_cell_collisions{cellid="b",earfcn="200"}`: 0,
+				`onos_xapppci_cell_collisions{cellid="c",earfcn="200"}`: 0,
+				`onos_xapppci_cell_collisions{cellid="d",earfcn="100"}`: 0,
+				`onos_xapppci_cell_confusions{cellid="a",earfcn="100"}`: 0,
+				`onos_xapppci_cell_confusions{cellid="a",earfcn="200"}`: 2,
+				`onos_xapppci_cell_confusions{cellid="b",earfcn="200"}`: 0,
+				`onos_xapppci_cell_confusions{cellid="c",earfcn="200"}`: 0,
+				`onos_xapppci_cell_confusions{cellid="d",earfcn="100"}`: 0,
+				`onos_xapppci_collision_cells{earfcn="100"}`:            0,
+				`onos_xapppci_collision_cells{earfcn="200"}`:            0,
+				`onos_xapppci_confusion_cells{earfcn="100"}`:            0,
+				`onos_xapppci_confusion_cells{earfcn="200"}`:            1,
+			},
+		},
+		{
+			name: "duplicate and unknown neighbors",
+			cells: []CellInfo{
+				pciCell("a", 1, 100, "b", "b", "x"),
+				pciCell("b", 1, 100),
+			},
+			expected: map[string]float64{
+				`onos_xapppci_cell_collisions{cellid="a",earfcn="100"}`: 1,
+				`onos_xapppci_cell_collisions{cellid="b",earfcn="100"}`: 0,
+				`onos_xapppci_cell_confusions{cellid="a",earfcn="100"}`: 0,
+				`onos_xapppci_cell_confusions{cellid="b",earfcn="100"}`: 0,
+				`onos_xapppci_collision_cells{earfcn="100"}`:            1,
+				`onos_xapppci_confusion_cells{earfcn="100"}`:            0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validationKPI := XappPciValidation()
+			validationKPI.Cells = make(map[string]CellInfo)
+			for _, cell := range test.cells {
+				validationKPI.Cells[cell.CellID] = cell
+			}
+
+			assert.Equal(t, test.expected, metricValues(t, validationKPI))
+		})
+	}
+}